@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+)
+
+// Config 服务配置
+//
+// 先读取配置文件（JSON），再用环境变量覆盖：
+//
+//	SWD_ADDR              监听地址
+//	SWD_DICT_PATHS        热加载的词库文件，以系统路径分隔符分隔
+//	SWD_RELOAD_INTERVAL   词库轮询间隔，如 5s
+//	SWD_SHUTDOWN_TIMEOUT  优雅关闭的最长等待时间
+//	SWD_MAX_BODY_BYTES    请求体大小上限
+//	SWD_ADMIN_TOKEN       修改词库所需的 Bearer 令牌
+//	SWD_LOG_LEVEL         日志级别：debug、info、warn 或 error
+//	SWD_OPTIONS           检测配置（JSON，字段同 options）
+type Config struct {
+	Addr            string          `json:"addr"`
+	DictPaths       []string        `json:"dict_paths"`
+	ReloadInterval  Duration        `json:"reload_interval"`
+	ShutdownTimeout Duration        `json:"shutdown_timeout"`
+	MaxBodyBytes    int64           `json:"max_body_bytes"`
+	AdminToken      string          `json:"admin_token"`
+	LogLevel        slog.Level      `json:"log_level"`
+	Options         core.SWDOptions `json:"options"`
+}
+
+// Duration 可从 "5s" 形式的字符串解析的时长
+type Duration time.Duration
+
+// UnmarshalJSON 解析时长字符串或纳秒数
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var n int64
+		if err := json.Unmarshal(data, &n); err != nil {
+			return fmt.Errorf("invalid duration: %s", data)
+		}
+		*d = Duration(n)
+		return nil
+	}
+	parsed, err := time.ParseDuration(text)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON 输出时长字符串
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// defaultConfig 返回默认配置
+func defaultConfig() Config {
+	return Config{
+		Addr:            ":8080",
+		ReloadInterval:  Duration(5 * time.Second),
+		ShutdownTimeout: Duration(10 * time.Second),
+		MaxBodyBytes:    1 << 20,
+	}
+}
+
+// loadConfig 读取配置文件（path 为空时跳过）并应用环境变量
+func loadConfig(path string, getenv func(string) string) (Config, error) {
+	cfg := defaultConfig()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to read config: %w", err)
+		}
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
+		}
+	}
+	if err := applyEnv(&cfg, getenv); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.validate()
+}
+
+// applyEnv 用环境变量覆盖配置
+func applyEnv(cfg *Config, getenv func(string) string) error {
+	if v := getenv("SWD_ADDR"); v != "" {
+		cfg.Addr = v
+	}
+	if v := getenv("SWD_DICT_PATHS"); v != "" {
+		cfg.DictPaths = filepath.SplitList(v)
+	}
+	for name, target := range map[string]*Duration{
+		"SWD_RELOAD_INTERVAL":  &cfg.ReloadInterval,
+		"SWD_SHUTDOWN_TIMEOUT": &cfg.ShutdownTimeout,
+	} {
+		if v := getenv(name); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*target = Duration(d)
+		}
+	}
+	if v := getenv("SWD_MAX_BODY_BYTES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid SWD_MAX_BODY_BYTES: %w", err)
+		}
+		cfg.MaxBodyBytes = n
+	}
+	if v := getenv("SWD_ADMIN_TOKEN"); v != "" {
+		cfg.AdminToken = v
+	}
+	if v := getenv("SWD_LOG_LEVEL"); v != "" {
+		if err := cfg.LogLevel.UnmarshalText([]byte(v)); err != nil {
+			return fmt.Errorf("invalid SWD_LOG_LEVEL: %w", err)
+		}
+	}
+	if v := strings.TrimSpace(getenv("SWD_OPTIONS")); v != "" {
+		if err := json.Unmarshal([]byte(v), &cfg.Options); err != nil {
+			return fmt.Errorf("invalid SWD_OPTIONS: %w", err)
+		}
+	}
+	return nil
+}
+
+// validate 校验配置
+func (c Config) validate() error {
+	switch {
+	case c.Addr == "":
+		return fmt.Errorf("addr cannot be empty")
+	case c.ReloadInterval <= 0:
+		return fmt.Errorf("reload_interval must be positive")
+	case c.ShutdownTimeout <= 0:
+		return fmt.Errorf("shutdown_timeout must be positive")
+	case c.MaxBodyBytes <= 0:
+		return fmt.Errorf("max_body_bytes must be positive")
+	}
+	return nil
+}
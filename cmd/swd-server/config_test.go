@@ -0,0 +1,77 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	content := `{
+		"addr": ":9000",
+		"dict_paths": ["a.txt"],
+		"reload_interval": "2s",
+		"options": {"ignore_case": true}
+	}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{
+		"SWD_ADDR":             ":9100",
+		"SWD_SHUTDOWN_TIMEOUT": "3s",
+		"SWD_OPTIONS":          `{"skip_whitespace": true}`,
+		"SWD_LOG_LEVEL":        "debug",
+	}
+	cfg, err := loadConfig(path, func(key string) string { return env[key] })
+	if err != nil {
+		t.Fatalf("loadConfig() 错误 = %v", err)
+	}
+
+	if cfg.Addr != ":9100" {
+		t.Errorf("Addr = %q, 期望环境变量覆盖为 :9100", cfg.Addr)
+	}
+	if len(cfg.DictPaths) != 1 || cfg.DictPaths[0] != "a.txt" {
+		t.Errorf("DictPaths = %v", cfg.DictPaths)
+	}
+	if time.Duration(cfg.ReloadInterval) != 2*time.Second || time.Duration(cfg.ShutdownTimeout) != 3*time.Second {
+		t.Errorf("ReloadInterval = %v, ShutdownTimeout = %v", cfg.ReloadInterval, cfg.ShutdownTimeout)
+	}
+	if !cfg.Options.IgnoreCase || !cfg.Options.SkipWhitespace {
+		t.Errorf("Options = %+v, 期望合并文件与环境变量", cfg.Options)
+	}
+	if cfg.LogLevel != slog.LevelDebug {
+		t.Errorf("LogLevel = %v, 期望 DEBUG", cfg.LogLevel)
+	}
+	if cfg.MaxBodyBytes != defaultConfig().MaxBodyBytes {
+		t.Errorf("MaxBodyBytes = %d, 期望默认值", cfg.MaxBodyBytes)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"非法时长", map[string]string{"SWD_RELOAD_INTERVAL": "soon"}},
+		{"非法大小", map[string]string{"SWD_MAX_BODY_BYTES": "big"}},
+		{"非法选项", map[string]string{"SWD_OPTIONS": "{"}},
+		{"非法日志级别", map[string]string{"SWD_LOG_LEVEL": "loud"}},
+		{"非正数", map[string]string{"SWD_RELOAD_INTERVAL": "-1s"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := loadConfig("", func(key string) string { return tt.env[key] }); err == nil {
+				t.Error("loadConfig() 应返回错误")
+			}
+		})
+	}
+
+	if _, err := loadConfig(filepath.Join(t.TempDir(), "missing.json"), func(string) string { return "" }); err == nil {
+		t.Error("配置文件不存在时应返回错误")
+	}
+}
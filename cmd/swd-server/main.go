@@ -0,0 +1,137 @@
+// Command swd-server 以 HTTP JSON 接口提供敏感词检测服务
+//
+// 接口：
+//
+//	POST   /detect   {"text": "...", "categories": ["gambling"]}  => {"detected": true}
+//	POST   /match    {"text": "...", "exclude": ["profanity"]}    => {"matches": [...]}
+//	POST   /replace  {"text": "...", "replacement": "*"}          => {"text": "..."}
+//	GET    /words?word=赌场                                        => 词条的分类与元数据
+//	GET    /words                                                  => 以 JSON 格式导出词库
+//	POST   /words    {"words": [{"word": "...", "category": "gambling"}]}
+//	DELETE /words    {"words": [{"word": "..."}]}
+//	GET    /healthz, /readyz                                       => 存活与就绪探针
+//
+// 配置见 Config，收到 SIGINT/SIGTERM 后先置为未就绪，再等待进行中的请求完成。
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/dictionary"
+	"github.com/ttofTnT/go-swd/pkg/swd"
+)
+
+func main() {
+	configPath := flag.String("config", "", "配置文件路径（JSON）")
+	flag.Parse()
+
+	cfg, err := loadConfig(*configPath, os.Getenv)
+	if err != nil {
+		slog.Error("invalid configuration", "error", err)
+		os.Exit(1)
+	}
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: cfg.LogLevel})))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, cfg, nil); err != nil {
+		slog.Error("swd-server exited", "error", err)
+		os.Exit(1)
+	}
+}
+
+// optionsFactory 以指定检测配置创建组件的工厂
+type optionsFactory struct {
+	swd.DefaultFactory
+	options core.SWDOptions
+}
+
+// BuildComponents 使用配置的检测选项创建组件
+func (f *optionsFactory) BuildComponents(options *core.SWDOptions) (core.Detector, core.Filter, core.Loader, error) {
+	*options = f.options
+	return f.DefaultFactory.BuildComponents(options)
+}
+
+// newEngine 创建检测引擎
+func newEngine(cfg Config) (*swd.SWD, error) {
+	options := cfg.Options
+	if options.Logger == nil {
+		options.Logger = slog.Default()
+	}
+	engine, err := swd.NewFromFactory(&optionsFactory{options: options})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create engine: %w", err)
+	}
+	return engine, nil
+}
+
+// run 启动服务直到 ctx 结束，然后优雅关闭；onReady 不为 nil 时在就绪后以实际监听地址调用
+func run(ctx context.Context, cfg Config, onReady func(addr net.Addr)) error {
+	engine, err := newEngine(cfg)
+	if err != nil {
+		return err
+	}
+	srv := newServer(engine, cfg)
+
+	if len(cfg.DictPaths) > 0 {
+		// 加载结果由加载器的日志记录器输出
+		watcher, err := engine.NewWatcher(cfg.DictPaths,
+			dictionary.WithInterval(time.Duration(cfg.ReloadInterval)),
+		)
+		if err != nil {
+			return err
+		}
+		if err := watcher.Load(ctx); err != nil {
+			return fmt.Errorf("failed to load dictionaries: %w", err)
+		}
+		go func() { _ = watcher.Watch(ctx) }()
+	}
+
+	listener, err := net.Listen("tcp", cfg.Addr)
+	if err != nil {
+		return err
+	}
+	httpServer := &http.Server{
+		Handler:           srv,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.Serve(listener)
+	}()
+	slog.Info("swd-server listening", "addr", listener.Addr().String())
+	srv.setReady(true)
+	if onReady != nil {
+		onReady(listener.Addr())
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	srv.setReady(false)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.ShutdownTimeout))
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("graceful shutdown failed: %w", err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	slog.Info("swd-server stopped")
+	return nil
+}
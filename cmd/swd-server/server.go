@@ -0,0 +1,326 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/swd"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// errConflictingFilters 同时指定了 categories 与 exclude
+var errConflictingFilters = errors.New("categories and exclude are mutually exclusive")
+
+// server HTTP 检测服务
+type server struct {
+	engine       *swd.SWD
+	adminToken   string
+	maxBodyBytes int64
+	ready        atomic.Bool
+	mux          *http.ServeMux
+}
+
+// newServer 创建检测服务，需调用 setReady 后 /readyz 才返回就绪
+func newServer(engine *swd.SWD, cfg Config) *server {
+	s := &server{
+		engine:       engine,
+		adminToken:   cfg.AdminToken,
+		maxBodyBytes: cfg.MaxBodyBytes,
+		mux:          http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/detect", s.handleDetect)
+	s.mux.HandleFunc("/match", s.handleMatch)
+	s.mux.HandleFunc("/replace", s.handleReplace)
+	s.mux.HandleFunc("/words", s.handleWords)
+	s.mux.HandleFunc("/healthz", s.handleHealth)
+	s.mux.HandleFunc("/readyz", s.handleReady)
+	return s
+}
+
+// ServeHTTP 实现 http.Handler
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// setReady 设置就绪状态，关闭前置为 false 使负载均衡摘除流量
+func (s *server) setReady(ready bool) {
+	s.ready.Store(ready)
+}
+
+// textRequest 检测类接口的请求体
+type textRequest struct {
+	Text        string   `json:"text"`
+	Categories  []string `json:"categories,omitempty"`  // 仅处理这些分类（标识或名称）
+	Exclude     []string `json:"exclude,omitempty"`     // 排除这些分类
+	Replacement string   `json:"replacement,omitempty"` // /replace 的替换内容，单个字符逐字替换，否则整词替换，默认 *
+}
+
+// filter 解析后的分类过滤条件
+type filter struct {
+	categories []category.Category
+	exclude    bool
+}
+
+// wordsRequest /words 的请求体
+type wordsRequest struct {
+	Words []wordEntry `json:"words"`
+}
+
+// wordEntry 添加或删除的词条，删除时忽略分类
+type wordEntry struct {
+	Word     string `json:"word"`
+	Category string `json:"category,omitempty"`
+}
+
+// handleDetect 处理 POST /detect
+func (s *server) handleDetect(w http.ResponseWriter, r *http.Request) {
+	req, f, ok := s.decodeText(w, r)
+	if !ok {
+		return
+	}
+	var detected bool
+	switch {
+	case f.categories == nil:
+		detected = s.engine.Detect(req.Text)
+	case f.exclude:
+		detected = s.engine.DetectNotIn(req.Text, f.categories...)
+	default:
+		detected = s.engine.DetectIn(req.Text, f.categories...)
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"detected": detected})
+}
+
+// handleMatch 处理 POST /match
+func (s *server) handleMatch(w http.ResponseWriter, r *http.Request) {
+	req, f, ok := s.decodeText(w, r)
+	if !ok {
+		return
+	}
+	var matches []core.SensitiveWord
+	switch {
+	case f.categories == nil:
+		matches = s.engine.MatchAll(req.Text)
+	case f.exclude:
+		matches = s.engine.MatchAllNotIn(req.Text, f.categories...)
+	default:
+		matches = s.engine.MatchAllIn(req.Text, f.categories...)
+	}
+	if matches == nil {
+		matches = []core.SensitiveWord{}
+	}
+	writeJSON(w, http.StatusOK, map[string][]core.SensitiveWord{"matches": matches})
+}
+
+// handleReplace 处理 POST /replace
+func (s *server) handleReplace(w http.ResponseWriter, r *http.Request) {
+	req, f, ok := s.decodeText(w, r)
+	if !ok {
+		return
+	}
+	strategy := swd.MaskStrategy(req.Replacement)
+
+	var text string
+	switch {
+	case f.categories == nil:
+		text = s.engine.ReplaceWithStrategy(req.Text, strategy)
+	case f.exclude:
+		text = s.engine.ReplaceWithStrategyNotIn(req.Text, strategy, f.categories...)
+	default:
+		text = s.engine.ReplaceWithStrategyIn(req.Text, strategy, f.categories...)
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"text": text})
+}
+
+// handleWords 处理 /words：GET 查询单个词条或导出词库，POST 添加，DELETE 删除
+func (s *server) handleWords(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.getWords(w, r)
+	case http.MethodPost, http.MethodDelete:
+		if s.adminToken == "" {
+			writeError(w, http.StatusForbidden, errors.New("word management is disabled: admin_token is not configured"))
+			return
+		}
+		if !s.authorized(r) {
+			writeError(w, http.StatusUnauthorized, errors.New("invalid admin token"))
+			return
+		}
+		var req wordsRequest
+		if !s.decode(w, r, &req) {
+			return
+		}
+		if r.Method == http.MethodPost {
+			s.addWords(w, req)
+		} else {
+			s.removeWords(w, req)
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	}
+}
+
+// getWords 查询单个词条（?word=），未指定时以 JSON 格式导出整个词库
+func (s *server) getWords(w http.ResponseWriter, r *http.Request) {
+	word := r.URL.Query().Get("word")
+	if word == "" {
+		w.Header().Set("Content-Type", "application/json")
+		if err := s.engine.Export(w, core.DictFormatJSON); err != nil {
+			slog.Error("failed to export dictionary", "error", err)
+		}
+		return
+	}
+
+	categories := s.engine.GetWordCategories(word)
+	if categories == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("word %q not found", word))
+		return
+	}
+	resp := struct {
+		Word       string              `json:"word"`
+		Categories []category.Category `json:"categories"`
+		Meta       *core.WordMeta      `json:"meta,omitempty"`
+	}{Word: word, Categories: categories}
+	if meta, ok := s.engine.GetWordMeta(word); ok {
+		resp.Meta = &meta
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// addWords 批量添加词条
+func (s *server) addWords(w http.ResponseWriter, req wordsRequest) {
+	reg := s.engine.Categories()
+	words := make(map[string]category.Category, len(req.Words))
+	for _, entry := range req.Words {
+		cat := category.Custom
+		if entry.Category != "" {
+			parsed, err := reg.ParseText(entry.Category)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+			cat = parsed
+		}
+		words[entry.Word] = cat
+	}
+	if err := s.engine.AddWords(words); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"added": len(words)})
+}
+
+// removeWords 批量删除词条
+func (s *server) removeWords(w http.ResponseWriter, req wordsRequest) {
+	words := make([]string, 0, len(req.Words))
+	for _, entry := range req.Words {
+		words = append(words, entry.Word)
+	}
+	if err := s.engine.RemoveWords(words); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int{"removed": len(words)})
+}
+
+// handleHealth 存活探针，进程能处理请求即返回 200
+func (s *server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleReady 就绪探针，词库加载完成且未在关闭时返回 200
+func (s *server) handleReady(w http.ResponseWriter, _ *http.Request) {
+	if !s.ready.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "not ready"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+// authorized 校验修改词库的令牌，调用方需确保已配置令牌
+func (s *server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) == 1
+}
+
+// decodeText 解析检测类接口的请求体和分类过滤条件，失败时已写入错误响应
+func (s *server) decodeText(w http.ResponseWriter, r *http.Request) (textRequest, filter, bool) {
+	var req textRequest
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return req, filter{}, false
+	}
+	if !s.decode(w, r, &req) {
+		return req, filter{}, false
+	}
+	f, err := s.parseFilter(req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return req, filter{}, false
+	}
+	return req, f, true
+}
+
+// parseFilter 解析分类过滤条件
+func (s *server) parseFilter(req textRequest) (filter, error) {
+	if len(req.Categories) > 0 && len(req.Exclude) > 0 {
+		return filter{}, errConflictingFilters
+	}
+	names, exclude := req.Categories, false
+	if len(req.Exclude) > 0 {
+		names, exclude = req.Exclude, true
+	}
+	if len(names) == 0 {
+		return filter{}, nil
+	}
+
+	reg := s.engine.Categories()
+	f := filter{categories: make([]category.Category, 0, len(names)), exclude: exclude}
+	for _, name := range names {
+		cat, err := reg.ParseText(name)
+		if err != nil {
+			return filter{}, err
+		}
+		f.categories = append(f.categories, cat)
+	}
+	return f, nil
+}
+
+// decode 以大小上限解析 JSON 请求体，失败时已写入错误响应
+func (s *server) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body := http.MaxBytesReader(w, r.Body, s.maxBodyBytes)
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, err)
+		} else {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		}
+		return false
+	}
+	return true
+}
+
+// writeJSON 写入 JSON 响应
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("failed to write response", "status", status, "error", err)
+	}
+}
+
+// writeError 写入 JSON 错误响应
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
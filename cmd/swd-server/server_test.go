@@ -0,0 +1,306 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+var (
+	testEngineOnce sync.Once
+	testSrv        *server
+	testSrvErr     error
+)
+
+// newTestServer 创建共享的测试服务，词库只包含测试词条
+func newTestServer(t *testing.T) *server {
+	t.Helper()
+	testEngineOnce.Do(func() {
+		cfg := defaultConfig()
+		cfg.AdminToken = "secret"
+		cfg.MaxBodyBytes = 256
+		cfg.Options.StripInvisible = true
+		engine, err := newEngine(cfg)
+		if err != nil {
+			testSrvErr = err
+			return
+		}
+		_ = engine.Clear()
+		if err := engine.AddWords(map[string]category.Category{
+			"赌场": category.Gambling,
+			"傻瓜": category.Profanity,
+		}); err != nil {
+			testSrvErr = err
+			return
+		}
+		testSrv = newServer(engine, cfg)
+		testSrv.setReady(true)
+	})
+	if testSrvErr != nil {
+		t.Fatalf("创建测试服务失败: %v", testSrvErr)
+	}
+	return testSrv
+}
+
+// do 发送请求并解析 JSON 响应
+func do(t *testing.T, h http.Handler, method, path, body string, out interface{}) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if method == http.MethodPost || method == http.MethodDelete {
+		req.Header.Set("Authorization", "Bearer secret")
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s 响应不是 JSON: %s", method, path, rec.Body.String())
+		}
+	}
+	return rec.Code
+}
+
+func TestServer_Detect(t *testing.T) {
+	srv := newTestServer(t)
+
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{"命中", `{"text":"去赌场"}`, true},
+		{"未命中", `{"text":"正常文本"}`, false},
+		{"分类过滤", `{"text":"去赌场","categories":["profanity"]}`, false},
+		{"中文分类名", `{"text":"去赌场","categories":["赌博"]}`, true},
+		{"排除分类", `{"text":"去赌场","exclude":["gambling"]}`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp struct{ Detected bool }
+			if code := do(t, srv, http.MethodPost, "/detect", tt.body, &resp); code != http.StatusOK {
+				t.Fatalf("状态码 = %d, 期望 200", code)
+			}
+			if resp.Detected != tt.want {
+				t.Errorf("detected = %v, 期望 %v", resp.Detected, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_BadRequests(t *testing.T) {
+	srv := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"方法错误", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"非法 JSON", http.MethodPost, `{`, http.StatusBadRequest},
+		{"未知字段", http.MethodPost, `{"txt":"a"}`, http.StatusBadRequest},
+		{"未知分类", http.MethodPost, `{"text":"a","categories":["unknown"]}`, http.StatusBadRequest},
+		{"过滤条件冲突", http.MethodPost, `{"text":"a","categories":["scam"],"exclude":["drugs"]}`, http.StatusBadRequest},
+		{"请求体过大", http.MethodPost, `{"text":"` + strings.Repeat("a", 512) + `"}`, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp struct{ Error string }
+			if code := do(t, srv, tt.method, "/detect", tt.body, &resp); code != tt.want {
+				t.Errorf("状态码 = %d, 期望 %d", code, tt.want)
+			}
+			if resp.Error == "" {
+				t.Error("错误响应缺少 error 字段")
+			}
+		})
+	}
+}
+
+func TestServer_MatchAndReplace(t *testing.T) {
+	srv := newTestServer(t)
+
+	var matched struct {
+		Matches []struct {
+			Word     string
+			Category string
+		}
+	}
+	do(t, srv, http.MethodPost, "/match", `{"text":"傻瓜去赌场","categories":["gambling"]}`, &matched)
+	if len(matched.Matches) != 1 || matched.Matches[0].Word != "赌场" || matched.Matches[0].Category != "gambling" {
+		t.Errorf("matches = %+v, 期望仅包含 赌场", matched.Matches)
+	}
+
+	var empty struct{ Matches []interface{} }
+	do(t, srv, http.MethodPost, "/match", `{"text":"正常"}`, &empty)
+	if empty.Matches == nil {
+		t.Error("无匹配时 matches 应为空列表")
+	}
+
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"text":"傻瓜去赌场"}`, "**去**"},
+		{`{"text":"傻瓜去赌场","replacement":"#"}`, "##去##"},
+		{`{"text":"傻\u200b瓜去赌场"}`, "***去**"},
+		{`{"text":"傻瓜去赌场","replacement":"[已屏蔽]","exclude":["profanity"]}`, "傻瓜去[已屏蔽]"},
+	}
+	for _, tt := range tests {
+		var resp struct{ Text string }
+		do(t, srv, http.MethodPost, "/replace", tt.body, &resp)
+		if resp.Text != tt.want {
+			t.Errorf("replace(%s) = %q, 期望 %q", tt.body, resp.Text, tt.want)
+		}
+	}
+}
+
+func TestServer_Words(t *testing.T) {
+	srv := newTestServer(t)
+
+	// 未授权
+	req := httptest.NewRequest(http.MethodPost, "/words", strings.NewReader(`{"words":[]}`))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("无令牌状态码 = %d, 期望 401", rec.Code)
+	}
+
+	// 未配置令牌时禁止修改词库
+	cfg := defaultConfig()
+	noToken := newServer(srv.engine, cfg)
+	for _, method := range []string{http.MethodPost, http.MethodDelete} {
+		if code := do(t, noToken, method, "/words", `{"words":[{"word":"老千"}]}`, nil); code != http.StatusForbidden {
+			t.Errorf("未配置令牌时 %s 状态码 = %d, 期望 403", method, code)
+		}
+	}
+
+	if code := do(t, srv, http.MethodPost, "/words", `{"words":[{"word":"老千","category":"gambling"},{"word":"竞品"}]}`, nil); code != http.StatusOK {
+		t.Fatalf("添加词条状态码 = %d", code)
+	}
+	if code := do(t, srv, http.MethodPost, "/words", `{"words":[{"word":"x","category":"unknown"}]}`, nil); code != http.StatusBadRequest {
+		t.Errorf("未知分类状态码 = %d, 期望 400", code)
+	}
+
+	var word struct {
+		Word       string
+		Categories []string
+	}
+	if code := do(t, srv, http.MethodGet, "/words?word=老千", "", &word); code != http.StatusOK {
+		t.Fatalf("查询词条状态码 = %d", code)
+	}
+	if len(word.Categories) != 1 || word.Categories[0] != "gambling" {
+		t.Errorf("categories = %v, 期望 [gambling]", word.Categories)
+	}
+
+	// 导出词库
+	var exported []map[string]interface{}
+	do(t, srv, http.MethodGet, "/words", "", &exported)
+	if len(exported) < 3 {
+		t.Errorf("导出词条数 = %d, 期望至少 3", len(exported))
+	}
+
+	if code := do(t, srv, http.MethodDelete, "/words", `{"words":[{"word":"老千"},{"word":"竞品"}]}`, nil); code != http.StatusOK {
+		t.Fatalf("删除词条状态码 = %d", code)
+	}
+	if code := do(t, srv, http.MethodGet, "/words?word=老千", "", nil); code != http.StatusNotFound {
+		t.Errorf("删除后查询状态码 = %d, 期望 404", code)
+	}
+	if code := do(t, srv, http.MethodPut, "/words", "", nil); code != http.StatusMethodNotAllowed {
+		t.Errorf("PUT 状态码 = %d, 期望 405", code)
+	}
+}
+
+func TestServer_Probes(t *testing.T) {
+	srv := newServer(newTestServer(t).engine, defaultConfig())
+
+	if code := do(t, srv, http.MethodGet, "/healthz", "", nil); code != http.StatusOK {
+		t.Errorf("/healthz 状态码 = %d, 期望 200", code)
+	}
+	if code := do(t, srv, http.MethodGet, "/readyz", "", nil); code != http.StatusServiceUnavailable {
+		t.Errorf("未就绪时 /readyz 状态码 = %d, 期望 503", code)
+	}
+	srv.setReady(true)
+	if code := do(t, srv, http.MethodGet, "/readyz", "", nil); code != http.StatusOK {
+		t.Errorf("就绪后 /readyz 状态码 = %d, 期望 200", code)
+	}
+}
+
+func TestRun_GracefulShutdown(t *testing.T) {
+	if testing.Short() {
+		t.Skip("跳过启动完整服务的测试")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gambling.txt")
+	if err := os.WriteFile(path, []byte("老虎机\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := defaultConfig()
+	cfg.Addr = "127.0.0.1:0"
+	cfg.DictPaths = []string{path}
+	cfg.ReloadInterval = Duration(10 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ready := make(chan net.Addr, 1)
+	done := make(chan error, 1)
+	go func() { done <- run(ctx, cfg, func(addr net.Addr) { ready <- addr }) }()
+
+	var addr net.Addr
+	select {
+	case addr = <-ready:
+	case err := <-done:
+		t.Fatalf("run() 提前退出: %v", err)
+	}
+
+	// 热加载的词库已生效
+	resp, err := http.Post("http://"+addr.String()+"/detect", "application/json", strings.NewReader(`{"text":"玩老虎机"}`))
+	if err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+	var detected struct{ Detected bool }
+	_ = json.NewDecoder(resp.Body).Decode(&detected)
+	resp.Body.Close()
+	if !detected.Detected {
+		t.Error("热加载的词条未被检测到")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("run() 错误 = %v", err)
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatal("run() 未在取消后退出")
+	}
+}
+
+func TestServer_ConcurrentRequests(t *testing.T) {
+	srv := newTestServer(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				rec := httptest.NewRecorder()
+				srv.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/detect", bytes.NewBufferString(`{"text":"去赌场"}`)))
+				if rec.Code != http.StatusOK {
+					t.Errorf("状态码 = %d", rec.Code)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,181 @@
+// Command swd 扫描文件、目录或标准输入中的敏感词
+//
+// 用法：
+//
+//	swd [flags] [path ...]
+//
+// 未指定路径或路径为 - 时读取标准输入，目录会被递归扫描（跳过隐藏目录和二进制文件）。
+// 匹配结果以 文件:行:列 定位，可输出为 text、jsonl 或 sarif。
+//
+// 退出码：0 未发现敏感词，1 发现敏感词，2 出错（即使同时发现了敏感词），
+// 便于在 pre-commit 钩子和批处理任务中使用。
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/detector"
+	"github.com/ttofTnT/go-swd/pkg/dictionary"
+	"github.com/ttofTnT/go-swd/pkg/filter"
+	"github.com/ttofTnT/go-swd/pkg/swd"
+)
+
+// 退出码
+const (
+	exitClean = 0 // 未发现敏感词
+	exitFound = 1 // 发现敏感词
+	exitError = 2 // 参数错误或扫描出错
+)
+
+// options 命令行参数
+type options struct {
+	format     string
+	categories string
+	exclude    string
+	profile    string
+	dicts      stringList
+	noDefault  bool
+	replace    string
+	write      bool
+}
+
+// stringList 可重复指定的参数
+type stringList []string
+
+// String 实现 flag.Value
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set 实现 flag.Value
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run 执行命令并返回退出码
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var opts options
+	fs := flag.NewFlagSet("swd", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.format, "format", "text", "输出格式：text、jsonl 或 sarif")
+	fs.StringVar(&opts.categories, "categories", "", "只报告这些分类，以逗号分隔（标识或名称）")
+	fs.StringVar(&opts.exclude, "exclude", "", "不报告这些分类，以逗号分隔，不能与 -categories 同时使用")
+	fs.StringVar(&opts.profile, "profile", "default", "检测配置：exact、default、strict，或 JSON 配置文件路径")
+	fs.Var(&opts.dicts, "dict", "额外加载的词库文件，可重复指定")
+	fs.BoolVar(&opts.noDefault, "no-default", false, "不使用内置词库，只使用 -dict 指定的词库")
+	fs.StringVar(&opts.replace, "replace", "", "输出替换敏感词后的内容而不是匹配结果，单个字符逐字替换，否则整词替换")
+	fs.BoolVar(&opts.write, "w", false, "与 -replace 一起使用时直接改写文件")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitClean
+		}
+		return exitError
+	}
+
+	s, err := newScanner(opts, stdin, stdout, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "swd: %v\n", err)
+		return exitError
+	}
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+	found, failed := s.scanAll(paths)
+	if err := s.out.close(); err != nil {
+		fmt.Fprintf(stderr, "swd: %v\n", err)
+		failed = true
+	}
+	switch {
+	case failed:
+		return exitError
+	case found:
+		return exitFound
+	default:
+		return exitClean
+	}
+}
+
+// loadProfile 返回内置检测配置，或从 JSON 文件读取
+func loadProfile(name string) (core.SWDOptions, error) {
+	if options, err := swd.ProfileOptions(swd.Profile(name)); err == nil {
+		return options, nil
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return core.SWDOptions{}, fmt.Errorf("unknown profile %q: %w", name, err)
+	}
+	var options core.SWDOptions
+	if err := json.Unmarshal(data, &options); err != nil {
+		return core.SWDOptions{}, fmt.Errorf("invalid profile %s: %w", name, err)
+	}
+	return options, nil
+}
+
+// optionsFactory 以指定检测配置创建组件的工厂
+//
+// 词库全部加载完成后才创建检测器，匹配算法只构建一次；noDefault 时不加载内置词库。
+type optionsFactory struct {
+	options   core.SWDOptions
+	noDefault bool
+	dicts     []string
+}
+
+// BuildComponents 实现 swd.Factory 接口
+func (f *optionsFactory) BuildComponents(options *core.SWDOptions) (core.Detector, core.Filter, core.Loader, error) {
+	*options = f.options
+
+	ctx := context.Background()
+	loader := dictionary.NewLoader()
+	if !f.noDefault {
+		if err := loader.LoadDefaultWords(ctx); err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: %w", swd.ErrDictionaryLoad, err)
+		}
+	}
+	for _, path := range f.dicts {
+		if err := loader.LoadFile(ctx, path); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	snapshot := loader.Snapshot()
+	d, err := detector.NewDetectorWithWords(*options, snapshot.Words, loader.Version())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %w", swd.ErrDetectorCreate, err)
+	}
+	d.(core.MetaObserver).OnMetaChanged(snapshot.Meta)
+	d.(core.CategoriesObserver).OnCategoriesChanged(snapshot.Categories)
+	loader.AddObserver(d.(core.Observer))
+
+	return d, filter.NewFilter(d), loader, nil
+}
+
+// newEngine 按命令行参数创建检测引擎并加载词库
+func newEngine(opts options) (*swd.SWD, error) {
+	detectOptions, err := loadProfile(opts.profile)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := swd.NewFromFactory(&optionsFactory{
+		options:   detectOptions,
+		noDefault: opts.noDefault,
+		dicts:     opts.dicts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create engine: %w", err)
+	}
+	return engine, nil
+}
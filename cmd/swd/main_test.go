@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setup 创建测试词库和待扫描的目录，返回词库参数与目录
+func setup(t *testing.T) ([]string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	dictDir := filepath.Join(dir, "dict")
+	srcDir := filepath.Join(dir, "src")
+	files := map[string]string{
+		filepath.Join(dictDir, "gambling.txt"):  "赌场\n",
+		filepath.Join(dictDir, "profanity.txt"): "傻瓜\n",
+		filepath.Join(srcDir, "a.txt"):          "第一行\n去赌场吗\n",
+		filepath.Join(srcDir, "clean.txt"):      "你好\n",
+		filepath.Join(srcDir, "sub", "b.md"):    "傻瓜",
+		filepath.Join(srcDir, ".git", "HEAD"):   "赌场",
+		filepath.Join(srcDir, "image.bin"):      "赌场\x00",
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	args := []string{
+		"-no-default",
+		"-dict", filepath.Join(dictDir, "gambling.txt"),
+		"-dict", filepath.Join(dictDir, "profanity.txt"),
+	}
+	return args, srcDir
+}
+
+// runCLI 执行命令，返回退出码、标准输出和标准错误
+func runCLI(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Text(t *testing.T) {
+	args, src := setup(t)
+
+	code, stdout, stderr := runCLI("", append(args, src)...)
+	if code != exitFound {
+		t.Fatalf("退出码 = %d, 期望 %d, stderr: %s", code, exitFound, stderr)
+	}
+	want := filepath.Join(src, "a.txt") + ":2:2: gambling 赌场\n" +
+		filepath.Join(src, "sub", "b.md") + ":1:1: profanity 傻瓜\n"
+	if stdout != want {
+		t.Errorf("输出 = %q, 期望 %q（应跳过隐藏目录和二进制文件）", stdout, want)
+	}
+
+	code, stdout, _ = runCLI("", append(args, "-categories", "profanity", filepath.Join(src, "a.txt"))...)
+	if code != exitClean || stdout != "" {
+		t.Errorf("按分类过滤后 退出码 = %d, 输出 = %q, 期望无匹配", code, stdout)
+	}
+}
+
+func TestRun_JSONLines(t *testing.T) {
+	args, _ := setup(t)
+
+	code, stdout, _ := runCLI("傻瓜去赌场", append(args, "-format", "jsonl", "-exclude", "profanity")...)
+	if code != exitFound {
+		t.Fatalf("退出码 = %d, 期望 %d", code, exitFound)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("输出 %d 行, 期望 1 行: %s", len(lines), stdout)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("解析 JSON 失败: %v", err)
+	}
+	if got["path"] != stdinName || got["line"] != 1.0 || got["column"] != 4.0 || got["word"] != "赌场" || got["category"] != "gambling" {
+		t.Errorf("JSON 输出 = %v", got)
+	}
+}
+
+func TestRun_SARIF(t *testing.T) {
+	args, src := setup(t)
+
+	code, stdout, _ := runCLI("", append(args, "-format", "sarif", filepath.Join(src, "a.txt"))...)
+	if code != exitFound {
+		t.Fatalf("退出码 = %d, 期望 %d", code, exitFound)
+	}
+	var log sarifLog
+	if err := json.Unmarshal([]byte(stdout), &log); err != nil {
+		t.Fatalf("解析 SARIF 失败: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("SARIF 日志 = %+v", log)
+	}
+	r := log.Runs[0]
+	if len(r.Tool.Driver.Rules) != 1 || r.Tool.Driver.Rules[0].ID != "gambling" {
+		t.Errorf("规则 = %+v, 期望只有 gambling", r.Tool.Driver.Rules)
+	}
+	if len(r.Results) != 1 {
+		t.Fatalf("结果 = %+v, 期望 1 个", r.Results)
+	}
+	region := r.Results[0].Locations[0].PhysicalLocation.Region
+	if region.StartLine != 2 || region.StartColumn != 2 || region.EndLine != 2 || region.EndColumn != 4 {
+		t.Errorf("区域 = %+v, 期望 2:2-2:4", region)
+	}
+
+	// 无匹配时仍输出合法的空日志
+	code, stdout, _ = runCLI("你好", append(args, "-format", "sarif")...)
+	if code != exitClean || !strings.Contains(stdout, `"results": []`) {
+		t.Errorf("无匹配时 退出码 = %d, 输出 = %s", code, stdout)
+	}
+}
+
+func TestRun_Replace(t *testing.T) {
+	args, src := setup(t)
+
+	code, stdout, _ := runCLI("傻瓜去赌场", append(args, "-replace", "*")...)
+	if code != exitFound || stdout != "**去**" {
+		t.Errorf("替换标准输入 退出码 = %d, 输出 = %q", code, stdout)
+	}
+
+	path := filepath.Join(src, "a.txt")
+	code, stdout, _ = runCLI("", append(args, "-replace", "[屏蔽]", "-w", path)...)
+	if code != exitFound || stdout != "" {
+		t.Errorf("改写文件 退出码 = %d, 输出 = %q", code, stdout)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "第一行\n去[屏蔽]吗\n" {
+		t.Errorf("改写后的内容 = %q", data)
+	}
+}
+
+// TestRun_ReplaceVariant 测试变体写法按原文长度替换，不残留原文字符
+func TestRun_ReplaceVariant(t *testing.T) {
+	args, src := setup(t)
+
+	path := filepath.Join(src, "variant.txt")
+	if err := os.WriteFile(path, []byte("去赌\u200b场吗\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, _, _ := runCLI("", append(args, "-replace", "*", "-w", path)...)
+	if code != exitFound {
+		t.Errorf("改写文件 退出码 = %d", code)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "去***吗\n" {
+		t.Errorf("改写后的内容 = %q, 期望 %q", data, "去***吗\n")
+	}
+}
+
+// TestRun_NoDefault 测试 -no-default 只使用 -dict 指定的词库
+func TestRun_NoDefault(t *testing.T) {
+	args, _ := setup(t)
+
+	if code, stdout, _ := runCLI("去赌钱", args...); code != exitClean {
+		t.Errorf("内置词条 退出码 = %d, 输出 = %q, 期望 %d", code, stdout, exitClean)
+	}
+	if code, _, _ := runCLI("去赌钱", "-dict", args[2]); code != exitFound {
+		t.Errorf("未指定 -no-default 时 退出码 = %d, 期望 %d", code, exitFound)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	args, src := setup(t)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"分类冲突", []string{"-categories", "gambling", "-exclude", "profanity"}},
+		{"未知分类", []string{"-categories", "unknown"}},
+		{"未知格式", []string{"-format", "xml"}},
+		{"未知配置", []string{"-profile", "missing"}},
+		{"缺少替换内容", []string{"-w"}},
+		{"文件不存在", []string{filepath.Join(src, "missing.txt")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runCLI("", append(append([]string{}, args...), tt.args...)...)
+			if code != exitError || stderr == "" {
+				t.Errorf("退出码 = %d, stderr = %q, 期望 %d 并输出错误", code, stderr, exitError)
+			}
+		})
+	}
+}
+
+func TestPositions(t *testing.T) {
+	pos := newPositions("ab\n中文\n\nx")
+	tests := []struct {
+		offset, line, column int
+	}{
+		{0, 1, 1},
+		{2, 1, 3},
+		{3, 2, 1},
+		{4, 2, 2},
+		{6, 3, 1},
+		{7, 4, 1},
+		{8, 4, 2},
+	}
+	for _, tt := range tests {
+		line, column := pos.at(tt.offset)
+		if line != tt.line || column != tt.column {
+			t.Errorf("at(%d) = %d:%d, 期望 %d:%d", tt.offset, line, column, tt.line, tt.column)
+		}
+	}
+}
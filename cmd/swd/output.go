@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// finding 带文件位置的匹配结果，行列号从 1 开始，列号以字符计，结束位置不包含在内
+type finding struct {
+	Path      string `json:"path"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	EndLine   int    `json:"end_line"`
+	EndColumn int    `json:"end_column"`
+	core.ReportMatch
+}
+
+// output 输出匹配结果
+type output interface {
+	add(f finding) error
+	// close 在所有输入扫描完成后调用
+	close() error
+}
+
+// newOutput 按格式名称创建输出
+func newOutput(format string, w io.Writer, reg *category.Registry) (output, error) {
+	switch format {
+	case "text":
+		return &textOutput{w: w, reg: reg}, nil
+	case "jsonl":
+		return &jsonlOutput{encoder: json.NewEncoder(w)}, nil
+	case "sarif":
+		return &sarifOutput{w: w, reg: reg}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// formatCategories 以逗号连接匹配结果的所有分类标识
+func formatCategories(reg *category.Registry, m core.ReportMatch) string {
+	cats := core.SensitiveWord{Category: m.Category, Categories: m.Categories}.AllCategories()
+	ids := make([]string, len(cats))
+	for i, cat := range cats {
+		ids[i] = reg.Format(cat)
+	}
+	return strings.Join(ids, ",")
+}
+
+// discardOutput 不输出匹配结果，用于 -replace
+type discardOutput struct{}
+
+// add 忽略匹配结果
+func (discardOutput) add(finding) error { return nil }
+
+// close 无需输出
+func (discardOutput) close() error { return nil }
+
+// textOutput 每行一个匹配结果：path:line:column: category word
+type textOutput struct {
+	w   io.Writer
+	reg *category.Registry
+}
+
+// add 输出一行匹配结果
+func (o *textOutput) add(f finding) error {
+	_, err := fmt.Fprintf(o.w, "%s:%d:%d: %s %s\n", f.Path, f.Line, f.Column, formatCategories(o.reg, f.ReportMatch), f.Text)
+	return err
+}
+
+// close 无需输出
+func (o *textOutput) close() error { return nil }
+
+// jsonlOutput 每行一个 JSON 对象，字段为 finding 与 core.ReportMatch 的 JSON 字段
+type jsonlOutput struct {
+	encoder *json.Encoder
+}
+
+// add 输出一个 JSON 对象
+func (o *jsonlOutput) add(f finding) error {
+	return o.encoder.Encode(f)
+}
+
+// close 无需输出
+func (o *jsonlOutput) close() error { return nil }
+
+// sarifOutput 在扫描结束后输出 SARIF 2.1.0 日志，每个分类对应一条规则
+type sarifOutput struct {
+	w       io.Writer
+	reg     *category.Registry
+	results []sarifResult
+	rules   map[string]bool
+}
+
+// SARIF 日志结构，只包含用到的字段
+type (
+	sarifLog struct {
+		Version string     `json:"version"`
+		Schema  string     `json:"$schema"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool       sarifTool     `json:"tool"`
+		ColumnKind string        `json:"columnKind"`
+		Results    []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           sarifRegion           `json:"region"`
+	}
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int          `json:"startLine"`
+		StartColumn int          `json:"startColumn"`
+		EndLine     int          `json:"endLine"`
+		EndColumn   int          `json:"endColumn"`
+		Snippet     sarifMessage `json:"snippet"`
+	}
+)
+
+// add 记录匹配结果及其分类对应的规则
+func (o *sarifOutput) add(f finding) error {
+	ruleID := o.reg.Format(f.Category)
+	if o.rules == nil {
+		o.rules = make(map[string]bool)
+	}
+	o.rules[ruleID] = true
+
+	// 配置为 block 的词条视为错误，其余为警告
+	level := "warning"
+	if f.Action == "block" {
+		level = "error"
+	}
+	o.results = append(o.results, sarifResult{
+		RuleID:  ruleID,
+		Level:   level,
+		Message: sarifMessage{Text: fmt.Sprintf("sensitive word %q (%s)", f.Word, formatCategories(o.reg, f.ReportMatch))},
+		Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(f.Path)},
+			Region: sarifRegion{
+				StartLine:   f.Line,
+				StartColumn: f.Column,
+				EndLine:     f.EndLine,
+				EndColumn:   f.EndColumn,
+				Snippet:     sarifMessage{Text: f.Text},
+			},
+		}}},
+	})
+	return nil
+}
+
+// close 输出完整的 SARIF 日志
+func (o *sarifOutput) close() error {
+	rules := make([]sarifRule, 0, len(o.rules))
+	for id := range o.rules {
+		rules = append(rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: "Sensitive word in category " + id}})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	results := o.results
+	if results == nil {
+		results = []sarifResult{}
+	}
+	log := sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "swd",
+				InformationURI: "https://github.com/ttofTnT/go-swd",
+				Rules:          rules,
+			}},
+			ColumnKind: "unicodeCodePoints",
+			Results:    results,
+		}},
+	}
+	encoder := json.NewEncoder(o.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/swd"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// stdinName 标准输入在输出中的名称
+const stdinName = "<stdin>"
+
+// binarySniffLen 判断二进制文件时检查的字节数
+const binarySniffLen = 8000
+
+var (
+	// errConflictingFilters 同时指定了 -categories 与 -exclude
+	errConflictingFilters = errors.New("-categories and -exclude are mutually exclusive")
+	// errWriteWithoutReplace -w 必须与 -replace 一起使用
+	errWriteWithoutReplace = errors.New("-w requires -replace")
+)
+
+// scanner 扫描输入并输出结果
+type scanner struct {
+	engine     *swd.SWD
+	categories []category.Category
+	exclude    bool
+	replace    string
+	write      bool
+	stdin      io.Reader
+	stdout     io.Writer
+	stderr     io.Writer
+	out        output
+}
+
+// newScanner 按命令行参数创建扫描器
+func newScanner(opts options, stdin io.Reader, stdout, stderr io.Writer) (*scanner, error) {
+	if opts.categories != "" && opts.exclude != "" {
+		return nil, errConflictingFilters
+	}
+	if opts.write && opts.replace == "" {
+		return nil, errWriteWithoutReplace
+	}
+	engine, err := newEngine(opts)
+	if err != nil {
+		return nil, err
+	}
+	s := &scanner{
+		engine:  engine,
+		replace: opts.replace,
+		write:   opts.write,
+		stdin:   stdin,
+		stdout:  stdout,
+		stderr:  stderr,
+	}
+
+	names, exclude := opts.categories, false
+	if opts.exclude != "" {
+		names, exclude = opts.exclude, true
+	}
+	if names != "" {
+		reg := engine.Categories()
+		for _, name := range strings.Split(names, ",") {
+			cat, err := reg.ParseText(strings.TrimSpace(name))
+			if err != nil {
+				return nil, err
+			}
+			s.categories = append(s.categories, cat)
+		}
+		s.exclude = exclude
+	}
+
+	if opts.replace != "" {
+		s.out = discardOutput{}
+	} else if s.out, err = newOutput(opts.format, stdout, engine.Categories()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// scanAll 扫描所有路径，返回是否发现敏感词以及是否出错，单个文件出错不影响其余文件
+func (s *scanner) scanAll(paths []string) (found, failed bool) {
+	for _, path := range paths {
+		if path == "-" {
+			data, err := io.ReadAll(s.stdin)
+			if err == nil {
+				found = s.scanContent(stdinName, data, false) || found
+				continue
+			}
+			s.fail(stdinName, err)
+			failed = true
+			continue
+		}
+
+		err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				s.fail(p, err)
+				failed = true
+				return nil
+			}
+			if d.IsDir() {
+				if p != path && strings.HasPrefix(d.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			hit, err := s.scanFile(p)
+			if err != nil {
+				s.fail(p, err)
+				failed = true
+			}
+			found = hit || found
+			return nil
+		})
+		if err != nil {
+			s.fail(path, err)
+			failed = true
+		}
+	}
+	return found, failed
+}
+
+// scanFile 扫描单个文件，跳过二进制文件
+func (s *scanner) scanFile(path string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	if isBinary(data) {
+		return false, nil
+	}
+	if !s.scanContent(path, data, true) {
+		return false, nil
+	}
+	if s.write {
+		info, err := os.Stat(path)
+		if err != nil {
+			return true, err
+		}
+		return true, os.WriteFile(path, []byte(s.replaceText(string(data))), info.Mode().Perm())
+	}
+	return true, nil
+}
+
+// scanContent 检测内容并输出匹配结果或替换后的内容，返回是否发现敏感词
+//
+// inPlace 为 true 且指定了 -w 时不输出替换后的内容，由调用方改写文件。
+func (s *scanner) scanContent(name string, data []byte, inPlace bool) bool {
+	text := string(data)
+	report, err := s.engine.Report(text)
+	if err != nil {
+		s.fail(name, err)
+		return false
+	}
+
+	pos := newPositions(text)
+	found := false
+	for _, m := range report.Matches {
+		if !s.keep(m) {
+			continue
+		}
+		found = true
+		line, column := pos.at(m.Start)
+		endLine, endColumn := pos.at(m.End)
+		if err := s.out.add(finding{
+			Path:        name,
+			Line:        line,
+			Column:      column,
+			EndLine:     endLine,
+			EndColumn:   endColumn,
+			ReportMatch: m,
+		}); err != nil {
+			s.fail(name, err)
+		}
+	}
+
+	if s.replace != "" && !(inPlace && s.write) {
+		if _, err := io.WriteString(s.stdout, s.replaceText(text)); err != nil {
+			s.fail(name, err)
+		}
+	}
+	return found
+}
+
+// keep 判断匹配结果是否满足分类过滤条件
+func (s *scanner) keep(m core.ReportMatch) bool {
+	if s.categories == nil {
+		return true
+	}
+	in := core.SensitiveWord{Category: m.Category, Categories: m.Categories}.In(s.categories...)
+	return in != s.exclude
+}
+
+// replaceText 按分类过滤条件替换，单个字符逐字替换，否则整词替换
+func (s *scanner) replaceText(text string) string {
+	strategy := swd.MaskStrategy(s.replace)
+	switch {
+	case s.categories == nil:
+		return s.engine.ReplaceWithStrategy(text, strategy)
+	case s.exclude:
+		return s.engine.ReplaceWithStrategyNotIn(text, strategy, s.categories...)
+	default:
+		return s.engine.ReplaceWithStrategyIn(text, strategy, s.categories...)
+	}
+}
+
+// fail 输出错误信息
+func (s *scanner) fail(name string, err error) {
+	fmt.Fprintf(s.stderr, "swd: %s: %v\n", name, err)
+}
+
+// isBinary 与 git 相同，前 8000 字节中包含 NUL 即视为二进制文件
+func isBinary(data []byte) bool {
+	if len(data) > binarySniffLen {
+		data = data[:binarySniffLen]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// positions 将字符偏移转换为行列号
+type positions struct {
+	lineStarts []int // 每行首字符的字符偏移
+}
+
+// newPositions 记录文本中每行的起始位置
+func newPositions(text string) positions {
+	p := positions{lineStarts: []int{0}}
+	offset := 0
+	for _, r := range text {
+		offset++
+		if r == '\n' {
+			p.lineStarts = append(p.lineStarts, offset)
+		}
+	}
+	return p
+}
+
+// at 返回字符偏移对应的行号和列号，均从 1 开始，列号以字符计
+func (p positions) at(offset int) (line, column int) {
+	i := sort.Search(len(p.lineStarts), func(i int) bool { return p.lineStarts[i] > offset }) - 1
+	return i + 1, offset - p.lineStarts[i] + 1
+}
@@ -1,7 +1,7 @@
 package algorithm
 
 import (
-	"log"
+	"unicode/utf8"
 
 	"github.com/ttofTnT/go-swd/pkg/core"
 	"github.com/ttofTnT/go-swd/pkg/types/category"
@@ -13,6 +13,7 @@ type AhoCorasickNode struct {
 	failLink *AhoCorasickNode          // 失败指针
 	isEnd    bool                      // 是否是单词结尾
 	word     string                    // 如果是结尾节点，存储完整词
+	length   int                       // 如果是结尾节点，存储词的字符数
 	category category.Category         // 敏感词分类
 	parent   *AhoCorasickNode          // 父节点 (用于重建单词)
 	depth    int                       // 在字典树中的深度
@@ -27,6 +28,7 @@ func newAhoCorasickNode() *AhoCorasickNode {
 
 // AhoCorasick Aho-Corasick算法实现
 type AhoCorasick struct {
+	hooks
 	root  *AhoCorasickNode
 	built bool // 是否已构建失败指针
 }
@@ -72,6 +74,7 @@ func (ac *AhoCorasick) insert(word string, category category.Category) {
 
 	current.isEnd = true
 	current.word = word
+	current.length = utf8.RuneCountInString(word)
 	current.category = category
 	ac.built = false // 需要重新构建失败指针
 }
@@ -124,9 +127,10 @@ func (ac *AhoCorasick) Match(text string) *core.SensitiveWord {
 	}
 
 	current := ac.root
-	runes := []rune(text)
+	pos := -1
 
-	for pos, char := range runes {
+	for _, char := range text {
+		pos++
 		// 查找下一个状态
 		for current != ac.root && current.children[char] == nil {
 			current = current.failLink
@@ -141,8 +145,7 @@ func (ac *AhoCorasick) Match(text string) *core.SensitiveWord {
 		// 检查当前节点的匹配
 		for node := current; node != ac.root; node = node.failLink {
 			if node.isEnd {
-				wordRunes := []rune(node.word)
-				startPos := pos - len(wordRunes) + 1
+				startPos := pos - node.length + 1
 				return &core.SensitiveWord{
 					Word:     node.word,
 					StartPos: startPos,
@@ -164,9 +167,11 @@ func (ac *AhoCorasick) MatchAll(text string) []core.SensitiveWord {
 
 	var matches []core.SensitiveWord
 	current := ac.root
-	runes := []rune(text)
+	pos := -1
 
-	for pos, char := range runes {
+	// 直接按字符遍历字符串，避免为每条文本分配 []rune
+	for _, char := range text {
+		pos++
 		// 查找下一个状态
 		for current != ac.root && current.children[char] == nil {
 			current = current.failLink
@@ -181,8 +186,7 @@ func (ac *AhoCorasick) MatchAll(text string) []core.SensitiveWord {
 		// 检查当前节点的所有匹配
 		for node := current; node != ac.root; node = node.failLink {
 			if node.isEnd {
-				wordRunes := []rune(node.word)
-				startPos := pos - len(wordRunes) + 1
+				startPos := pos - node.length + 1
 				match := core.SensitiveWord{
 					Word:     node.word,
 					StartPos: startPos,
@@ -197,6 +201,80 @@ func (ac *AhoCorasick) MatchAll(text string) []core.SensitiveWord {
 	return matches
 }
 
+// MatchAllCandidates 实现 core.CandidateMatcher，同时跟踪所有候选组合到达的状态
+//
+// 不同组合到达同一状态后的匹配过程完全相同，因此只保存去重后的状态集合，
+// 每个字符的开销与状态数和候选数成正比，不会随组合数指数增长。
+func (ac *AhoCorasick) MatchAllCandidates(text string, subs core.Substitutions) []core.SensitiveWord {
+	if !ac.built {
+		ac.buildFailureLinks()
+	}
+
+	var matches []core.SensitiveWord
+	states := []*AhoCorasickNode{ac.root}
+	var next, visited []*AhoCorasickNode
+	pos := -1
+
+	for _, char := range text {
+		pos++
+		next = next[:0]
+		for _, state := range states {
+			next = appendState(next, ac.step(state, char))
+			for _, alt := range subs[char] {
+				next = appendState(next, ac.step(state, alt))
+			}
+		}
+		states, next = next, states
+
+		// 沿失败指针报告匹配，多个状态共享的后缀只报告一次
+		visited = visited[:0]
+		for _, state := range states {
+			for node := state; node != ac.root && !containsState(visited, node); node = node.failLink {
+				visited = append(visited, node)
+				if node.isEnd {
+					matches = append(matches, core.SensitiveWord{
+						Word:     node.word,
+						StartPos: pos - node.length + 1,
+						EndPos:   pos + 1,
+						Category: node.category,
+					})
+				}
+			}
+		}
+	}
+
+	return matches
+}
+
+// step 返回从 state 读入字符 char 后到达的状态
+func (ac *AhoCorasick) step(state *AhoCorasickNode, char rune) *AhoCorasickNode {
+	for state != ac.root && state.children[char] == nil {
+		state = state.failLink
+	}
+	if next, exists := state.children[char]; exists {
+		return next
+	}
+	return ac.root
+}
+
+// containsState 判断状态集合中是否已有该状态
+func containsState[T comparable](states []T, state T) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// appendState 向状态集合中添加不重复的状态
+func appendState[T comparable](states []T, state T) []T {
+	if containsState(states, state) {
+		return states
+	}
+	return append(states, state)
+}
+
 // Replace 替换敏感词
 func (ac *AhoCorasick) Replace(text string, replacement rune) string {
 	matches := ac.MatchAll(text)
@@ -221,7 +299,7 @@ func (ac *AhoCorasick) Detect(text string) bool {
 // OnWordsChanged 实现 Observer 接口,当词库变更时重建算法
 func (ac *AhoCorasick) OnWordsChanged(words map[string]category.Category) {
 	if err := ac.Build(words); err != nil {
-		// 这里只能记录错误,因为是回调方法
-		log.Printf("重建算法失败: %v", err)
+		// 回调方法无法返回错误，交给日志和错误回调
+		ac.rebuildFailed(ac.Type(), len(words), err)
 	}
 }
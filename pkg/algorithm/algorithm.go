@@ -0,0 +1,23 @@
+package algorithm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+)
+
+// ErrUnsupported 不支持的算法类型
+var ErrUnsupported = errors.New("unsupported algorithm")
+
+// New 按类型创建匹配算法，类型为空时使用 Aho-Corasick
+func New(algo core.AlgorithmType) (core.Algorithm, error) {
+	switch algo {
+	case "", core.AlgorithmAhoCorasick:
+		return NewAhoCorasick(), nil
+	case core.AlgorithmTrie:
+		return NewTrie(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupported, algo)
+	}
+}
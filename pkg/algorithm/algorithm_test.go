@@ -1,7 +1,11 @@
 package algorithm
 
 import (
+	"bytes"
+	"errors"
+	"log/slog"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ttofTnT/go-swd/pkg/core"
@@ -192,6 +196,53 @@ func TestMatchAll(t *testing.T) {
 	}
 }
 
+// TestMatchAllCandidates 测试按候选字符匹配
+func TestMatchAllCandidates(t *testing.T) {
+	words := map[string]category.Category{
+		"fuck": category.Profanity,
+		"shit": category.Profanity,
+		"sex":  category.Pornography,
+		"fool": category.Profanity,
+		"ill":  category.Profanity,
+	}
+	subs := core.Substitutions{'4': {'u', 'a'}, '$': {'s'}, '5': {'s'}, '0': {'o'}, '1': {'i', 'l'}}
+
+	for _, alg := range getAlgorithms(t) {
+		t.Run(string(alg.Type()), func(t *testing.T) {
+			if err := alg.Build(words); err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			matcher, ok := alg.(core.CandidateMatcher)
+			if !ok {
+				t.Fatalf("%s does not implement core.CandidateMatcher", alg.Type())
+			}
+
+			got := matcher.MatchAllCandidates("f4ck $hit 5ex f00l 111", subs)
+			want := []core.SensitiveWord{
+				{Word: "fuck", StartPos: 0, EndPos: 4, Category: category.Profanity},
+				{Word: "shit", StartPos: 5, EndPos: 9, Category: category.Profanity},
+				{Word: "sex", StartPos: 10, EndPos: 13, Category: category.Pornography},
+				{Word: "fool", StartPos: 14, EndPos: 18, Category: category.Profanity},
+				{Word: "ill", StartPos: 19, EndPos: 22, Category: category.Profanity},
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("MatchAllCandidates() = %v, want %v", got, want)
+			}
+
+			// 没有替换表时与 MatchAll 一致
+			if got := matcher.MatchAllCandidates("f4ck fool", nil); len(got) != 1 || got[0].Word != "fool" {
+				t.Errorf("MatchAllCandidates(nil) = %v, want only fool", got)
+			}
+
+			// 每个字符两个候选，组合数为 2^n，状态集合保持线性开销
+			long := strings.Repeat("1", 5000)
+			if got := matcher.MatchAllCandidates(long, subs); len(got) != len(long)-2 {
+				t.Errorf("MatchAllCandidates(long) found %d matches, want %d", len(got), len(long)-2)
+			}
+		})
+	}
+}
+
 // TestReplace 测试敏感词替换
 func TestReplace(t *testing.T) {
 	tests := []algorithmTest{
@@ -227,3 +278,30 @@ func TestReplace(t *testing.T) {
 		}
 	}
 }
+
+// TestHooks_RebuildFailed 测试重建失败时记录日志并调用错误回调
+func TestHooks_RebuildFailed(t *testing.T) {
+	var buf bytes.Buffer
+	ac := NewAhoCorasick()
+	ac.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	var got error
+	ac.SetErrorHandler(func(err error) { got = err })
+
+	cause := errors.New("out of memory")
+	ac.rebuildFailed(ac.Type(), 3, cause)
+	if !errors.Is(got, cause) {
+		t.Errorf("错误回调收到 %v, 期望包装 %v", got, cause)
+	}
+	if log := buf.String(); !strings.Contains(log, `msg="failed to rebuild matcher"`) || !strings.Contains(log, "words=3") {
+		t.Errorf("日志 = %q, 缺少重建失败记录", log)
+	}
+
+	// 移除回调后只记录日志
+	ac.SetErrorHandler(nil)
+	got = nil
+	ac.rebuildFailed(ac.Type(), 3, cause)
+	if got != nil {
+		t.Errorf("移除回调后仍被调用: %v", got)
+	}
+}
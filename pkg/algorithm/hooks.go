@@ -0,0 +1,41 @@
+package algorithm
+
+import (
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+)
+
+// hooks 作为观察者在回调中重建失败时的日志与错误回调，嵌入到各算法实现中
+type hooks struct {
+	logger  atomic.Pointer[slog.Logger]
+	onError atomic.Pointer[func(error)]
+}
+
+// SetLogger 实现 core.LoggerSetter，设置重建时使用的日志记录器
+func (h *hooks) SetLogger(logger *slog.Logger) {
+	h.logger.Store(logger)
+}
+
+// SetErrorHandler 设置 OnWordsChanged 重建失败时的回调，为 nil 时只记录日志
+func (h *hooks) SetErrorHandler(fn func(error)) {
+	if fn == nil {
+		h.onError.Store(nil)
+		return
+	}
+	h.onError.Store(&fn)
+}
+
+// rebuildFailed 记录重建失败并调用错误回调
+func (h *hooks) rebuildFailed(algo core.AlgorithmType, words int, err error) {
+	logger := h.logger.Load()
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.Error("failed to rebuild matcher", "algorithm", algo, "words", words, "error", err)
+	if fn := h.onError.Load(); fn != nil {
+		(*fn)(fmt.Errorf("failed to rebuild %s: %w", algo, err))
+	}
+}
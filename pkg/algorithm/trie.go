@@ -1,8 +1,6 @@
 package algorithm
 
 import (
-	"log"
-
 	"github.com/ttofTnT/go-swd/pkg/core"
 	"github.com/ttofTnT/go-swd/pkg/types/category"
 )
@@ -24,6 +22,7 @@ func newTrieNode() *TrieNode {
 
 // Trie 字典树实现
 type Trie struct {
+	hooks
 	root *TrieNode
 }
 
@@ -106,6 +105,48 @@ func (t *Trie) MatchAll(text string) []core.SensitiveWord {
 	return matches
 }
 
+// MatchAllCandidates 实现 core.CandidateMatcher，与 MatchAll 一样在每个起始位置返回最短的匹配
+//
+// 从每个起始位置同时沿所有候选字符向下查找，前缀树中每个节点只对应一个前缀，
+// 状态数不超过节点数，不会随组合数指数增长。
+func (t *Trie) MatchAllCandidates(text string, subs core.Substitutions) []core.SensitiveWord {
+	var matches []core.SensitiveWord
+	var states, next []*TrieNode
+	runes := []rune(text)
+
+	for start := range runes {
+		states = append(states[:0], t.root)
+	scan:
+		for i := start; i < len(runes) && len(states) > 0; i++ {
+			next = next[:0]
+			for _, state := range states {
+				if child, exists := state.children[runes[i]]; exists {
+					next = appendState(next, child)
+				}
+				for _, alt := range subs[runes[i]] {
+					if child, exists := state.children[alt]; exists {
+						next = appendState(next, child)
+					}
+				}
+			}
+			states, next = next, states
+
+			for _, state := range states {
+				if state.isEnd {
+					matches = append(matches, core.SensitiveWord{
+						Word:     state.word,
+						StartPos: start,
+						EndPos:   i + 1,
+						Category: state.category,
+					})
+					break scan
+				}
+			}
+		}
+	}
+	return matches
+}
+
 // Replace 替换敏感词
 func (t *Trie) Replace(text string, replacement rune) string {
 	matches := t.MatchAll(text)
@@ -130,7 +171,7 @@ func (t *Trie) Detect(text string) bool {
 // OnWordsChanged 实现 Observer 接口,当词库变更时重建算法
 func (t *Trie) OnWordsChanged(words map[string]category.Category) {
 	if err := t.Build(words); err != nil {
-		// 这里只能记录错误,因为是回调方法
-		log.Printf("重建算法失败: %v", err)
+		// 回调方法无法返回错误，交给日志和错误回调
+		t.rebuildFailed(t.Type(), len(words), err)
 	}
 }
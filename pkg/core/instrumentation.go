@@ -0,0 +1,42 @@
+package core
+
+import (
+	"time"
+
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// Instrumentation 指标采集接口，检测器、过滤器和加载器在相应事件发生后调用
+//
+// 实现需可并发调用，且不应阻塞或修改传入的数据。
+type Instrumentation interface {
+	// ObserveScan 一次检测完成，op 为检测方法名（如 "MatchAll"），matches 为返回给调用方的匹配结果
+	ObserveScan(op string, matches []SensitiveWord, elapsed time.Duration)
+	// ObserveReplace 一次替换完成，replaced 为被替换的敏感词数量
+	ObserveReplace(replaced int, elapsed time.Duration)
+	// ObserveRebuild 检测算法重建完成，words 为词条数量，err 为重建失败的原因
+	ObserveRebuild(words int, elapsed time.Duration, err error)
+	// ObserveDictionary 词库变更后的大小，perCategory 为各分类的词条数，属于多个分类的词条在每个分类中各计一次
+	ObserveDictionary(total int, perCategory map[category.Category]int)
+}
+
+// Instrumented 可选接口，组件实现后可设置指标采集
+type Instrumented interface {
+	// SetInstrumentation 设置指标采集，为 nil 时停止采集
+	SetInstrumentation(inst Instrumentation)
+}
+
+// NopInstrumentation 不做任何处理的指标采集，可嵌入只关心部分事件的实现中
+type NopInstrumentation struct{}
+
+// ObserveScan 实现 Instrumentation
+func (NopInstrumentation) ObserveScan(string, []SensitiveWord, time.Duration) {}
+
+// ObserveReplace 实现 Instrumentation
+func (NopInstrumentation) ObserveReplace(int, time.Duration) {}
+
+// ObserveRebuild 实现 Instrumentation
+func (NopInstrumentation) ObserveRebuild(int, time.Duration, error) {}
+
+// ObserveDictionary 实现 Instrumentation
+func (NopInstrumentation) ObserveDictionary(int, map[category.Category]int) {}
@@ -2,24 +2,120 @@ package core
 
 import (
 	"context"
+	"errors"
+	"io"
+	"log/slog"
 
 	"github.com/ttofTnT/go-swd/pkg/types/category"
 )
 
 // SensitiveWord 敏感词匹配结果
+//
+// JSON 序列化时分类输出为 ASCII 标识（如 "pornography"）。
 type SensitiveWord struct {
-	Word     string
-	StartPos int
-	EndPos   int
-	Category category.Category
+	Word     string            `json:"word"`
+	StartPos int               `json:"start_pos"`
+	EndPos   int               `json:"end_pos"`
+	Category category.Category `json:"category"`
+	Meta     *WordMeta         `json:"meta,omitempty"` // 词条元数据，未配置时为 nil
+
+	Categories []category.Category `json:"categories,omitempty"` // 属于多个分类时的全部分类，此时 Category 为其中序号最小的一个
+
+	DictVersion uint64 `json:"dict_version,omitempty"` // 产生该结果的词库版本，0 表示未知
+}
+
+// WordMeta 敏感词词条元数据
+type WordMeta struct {
+	Severity    int      `json:"severity,omitempty"`    // 严重程度
+	Action      string   `json:"action,omitempty"`      // 处置动作（如 block、review）
+	Replacement string   `json:"replacement,omitempty"` // 替换文本
+	Tags        []string `json:"tags,omitempty"`        // 标签
+	Note        string   `json:"note,omitempty"`        // 备注
 }
 
+// IsZero 判断元数据是否为空
+func (m WordMeta) IsZero() bool {
+	return m.Severity == 0 && m.Action == "" && m.Replacement == "" && len(m.Tags) == 0 && m.Note == ""
+}
+
+// AllCategories 返回匹配结果所属的全部分类
+func (w SensitiveWord) AllCategories() []category.Category {
+	if len(w.Categories) > 0 {
+		return w.Categories
+	}
+	return []category.Category{w.Category}
+}
+
+// In 判断匹配结果是否属于任一指定分类
+func (w SensitiveWord) In(categories ...category.Category) bool {
+	for _, own := range w.AllCategories() {
+		for _, cat := range categories {
+			if cat.Contains(own) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Weight 返回词条的风险权重，未设置严重程度时为 1
+func (w SensitiveWord) Weight() float64 {
+	if w.Meta != nil && w.Meta.Severity > 0 {
+		return float64(w.Meta.Severity)
+	}
+	return 1
+}
+
+// ScoreAggregation 风险评分的聚合方式
+type ScoreAggregation string
+
+const (
+	ScoreMax        ScoreAggregation = "max"         // 取最高权重
+	ScoreSum        ScoreAggregation = "sum"         // 权重求和
+	ScoreDecayedSum ScoreAggregation = "decayed-sum" // 按权重降序衰减求和
+)
+
+// RiskScore 文本风险评分
+type RiskScore struct {
+	Total      float64                       `json:"total"`      // 总分
+	Categories map[category.Category]float64 `json:"categories"` // 各分类得分，JSON 中以 ASCII 标识为键
+	Matches    []SensitiveWord               `json:"matches"`    // 参与评分的匹配结果
+}
+
+// DictFormat 词库文件格式
+type DictFormat string
+
+const (
+	DictFormatText DictFormat = "text" // 每行一个词条，可带 | 分隔的元数据
+	DictFormatJSON DictFormat = "json" // JSON 词条数组
+	DictFormatCSV  DictFormat = "csv"  // 带表头的 CSV
+)
+
 // Observer 状态变更观察者接口
 type Observer interface {
 	// OnWordsChanged 词库变更时的回调
 	OnWordsChanged(words map[string]category.Category)
 }
 
+// VersionObserver 带版本号的词库变更观察者接口，实现该接口的观察者不再收到 OnWordsChanged
+type VersionObserver interface {
+	Observer
+	// OnVersionChanged 词库变更时的回调，version 为变更后的词库版本
+	OnVersionChanged(version uint64, words map[string]category.Category)
+}
+
+// MetaObserver 词条元数据变更观察者接口
+type MetaObserver interface {
+	// OnMetaChanged 词条元数据变更时的回调
+	OnMetaChanged(meta map[string]WordMeta)
+}
+
+// CategoriesObserver 多分类词条变更观察者接口
+type CategoriesObserver interface {
+	// OnCategoriesChanged 词条分类变更时的回调，仅包含属于多个分类的词条
+	OnCategoriesChanged(categories map[string][]category.Category)
+}
+
 // Detector 敏感词检测器
 type Detector interface {
 	// Detect 检查文本是否包含敏感词
@@ -28,17 +124,28 @@ type Detector interface {
 	// DetectIn 检查文本是否包含指定分类的敏感词
 	DetectIn(text string, categories ...category.Category) bool
 
+	// DetectNotIn 检查文本是否包含指定分类以外的敏感词
+	DetectNotIn(text string, categories ...category.Category) bool
+
 	// Match 返回文本中第一个敏感词
 	Match(text string) *SensitiveWord
 
 	// MatchIn 返回文本中第一个指定分类的敏感词
 	MatchIn(text string, categories ...category.Category) *SensitiveWord
 
+	// MatchNotIn 返回文本中第一个指定分类以外的敏感词
+	MatchNotIn(text string, categories ...category.Category) *SensitiveWord
+
 	// MatchAll 返回文本中所有敏感词
 	MatchAll(text string) []SensitiveWord
 
 	// MatchAllIn 返回文本中所有指定分类的敏感词
 	MatchAllIn(text string, categories ...category.Category) []SensitiveWord
+
+	// MatchAllNotIn 返回文本中所有指定分类以外的敏感词
+	MatchAllNotIn(text string, categories ...category.Category) []SensitiveWord
+	// Score 计算文本的风险评分
+	Score(text string) RiskScore
 }
 
 // Filter 敏感词过滤器
@@ -49,17 +156,44 @@ type Filter interface {
 	// ReplaceIn 使用指定的替换字符替换指定分类的敏感词
 	ReplaceIn(text string, replacement rune, categories ...category.Category) string
 
+	// ReplaceNotIn 使用指定的替换字符替换指定分类以外的敏感词
+	ReplaceNotIn(text string, replacement rune, categories ...category.Category) string
+
 	// ReplaceWithAsterisk 使用 * 号替换敏感词
 	ReplaceWithAsterisk(text string) string
 
 	// ReplaceWithAsteriskIn 使用 * 号替换指定分类的敏感词
 	ReplaceWithAsteriskIn(text string, categories ...category.Category) string
 
+	// ReplaceWithAsteriskNotIn 使用 * 号替换指定分类以外的敏感词
+	ReplaceWithAsteriskNotIn(text string, categories ...category.Category) string
+
 	// ReplaceWithStrategy 使用自定义替换策略替换敏感词
 	ReplaceWithStrategy(text string, strategy func(word SensitiveWord) string) string
 
 	// ReplaceWithStrategyIn 使用自定义替换策略替换指定分类的敏感词
 	ReplaceWithStrategyIn(text string, strategy func(word SensitiveWord) string, categories ...category.Category) string
+
+	// ReplaceWithStrategyNotIn 使用自定义替换策略替换指定分类以外的敏感词
+	ReplaceWithStrategyNotIn(text string, strategy func(word SensitiveWord) string, categories ...category.Category) string
+}
+
+// CategoryToggler 支持在全局范围内启用、停用分类的组件
+//
+// 停用的分类仍保留在词库中，重新启用后立即生效。
+type CategoryToggler interface {
+	// EnableCategories 重新启用指定分类
+	EnableCategories(categories ...category.Category)
+	// DisableCategories 停用指定分类及其子分类
+	DisableCategories(categories ...category.Category)
+	// DisabledCategories 返回已停用的分类
+	DisabledCategories() *category.Set
+}
+
+// LoggerSetter 可选接口，创建时无法获得 SWDOptions 的组件（如加载器、匹配算法）实现后可设置结构化日志
+type LoggerSetter interface {
+	// SetLogger 设置日志记录器，为 nil 时使用 slog.Default()
+	SetLogger(logger *slog.Logger)
 }
 
 // WordManager 敏感词管理接口
@@ -89,8 +223,45 @@ type Loader interface {
 	// LoadCustomWords 加载自定义词库
 	LoadCustomWords(ctx context.Context, words map[string]category.Category) error
 
+	// LoadFrom 按指定格式从 Reader 加载词库
+	LoadFrom(ctx context.Context, r io.Reader, format DictFormat) error
+	// LoadFile 加载词库文件，格式由扩展名决定
+	LoadFile(ctx context.Context, path string) error
 	// GetWords 获取所有已加载的敏感词
 	GetWords() map[string]category.Category
+	// GetWordMeta 获取词条元数据
+	GetWordMeta(word string) (WordMeta, bool)
+	// GetWordCategories 获取词条的全部分类
+	GetWordCategories(word string) []category.Category
+	// Export 按指定格式导出当前词库，输出按分类、词条排序
+	Export(w io.Writer, format DictFormat) error
+}
+
+// ErrNotModified 词库来源自上次拉取后未发生变更
+var ErrNotModified = errors.New("word source not modified")
+
+// WordSnapshot 词库来源的一份完整快照
+type WordSnapshot struct {
+	Version    string                         // 版本标识（如 ETag）
+	Words      map[string]category.Category   // 词条及主分类
+	Meta       map[string]WordMeta            // 词条元数据
+	Categories map[string][]category.Category // 属于多个分类的词条的全部分类，可为 nil
+}
+
+// WordSource 远程词库来源接口
+type WordSource interface {
+	// Fetch 拉取最新词库快照，未变更时返回 ErrNotModified
+	Fetch(ctx context.Context) (*WordSnapshot, error)
+	// Version 返回最近一次成功拉取的版本标识
+	Version() string
+}
+
+// SnapshotCommitter 需要确认快照已应用的词库来源，加载器在快照应用成功后调用 Commit
+//
+// 来源应在 Commit 之后才更新条件请求所用的版本标识，快照应用失败时下次拉取仍返回完整内容。
+type SnapshotCommitter interface {
+	// Commit 确认快照已成功应用
+	Commit(snapshot *WordSnapshot)
 }
 
 // StateManager 状态管理接口
@@ -115,17 +286,43 @@ type SWD interface {
 
 // SWDOptions 定义引擎的配置选项
 type SWDOptions struct {
-	IgnoreCase         bool // 忽略大小写
-	IgnoreWidth        bool // 忽略全角和半角字符差异
-	IgnoreNumStyle     bool // 忽略数字样式差异
-	EnableNumCheck     bool // 启用对连续数字的检测
-	EnableURLCheck     bool // 启用对 URL 的检测
-	EnableEmailCheck   bool // 启用对 Email 的检测
-	SkipWhitespace     bool // 忽略空白字符
-	MaxDistance        int  // 字符间最大距离（防止 f*u*c*k）
-	EnablePinyin       bool // 启用拼音检测
-	EnableHomophone    bool // 启用同音字检测
-	EnableSimilarShape bool // 启用形近字检测（如：幾/几）
-	EnableVariantForm  bool // 启用异体字检测（如：门/門）
-	EnableZhPYMix      bool // 启用中文拼音混合检测（如：fa票）
+	IgnoreCase         bool `json:"ignore_case,omitempty"`          // 忽略大小写
+	IgnoreWidth        bool `json:"ignore_width,omitempty"`         // 忽略全角和半角字符差异
+	IgnoreNumStyle     bool `json:"ignore_num_style,omitempty"`     // 忽略数字样式差异
+	EnableNumCheck     bool `json:"enable_num_check,omitempty"`     // 启用对连续数字的检测
+	EnableURLCheck     bool `json:"enable_url_check,omitempty"`     // 启用对 URL 的检测
+	EnableEmailCheck   bool `json:"enable_email_check,omitempty"`   // 启用对 Email 的检测
+	SkipWhitespace     bool `json:"skip_whitespace,omitempty"`      // 忽略空白字符
+	MaxDistance        int  `json:"max_distance,omitempty"`         // 字符间最大距离（防止 f*u*c*k）
+	EnablePinyin       bool `json:"enable_pinyin,omitempty"`        // 启用拼音检测
+	EnableHomophone    bool `json:"enable_homophone,omitempty"`     // 启用同音字检测
+	EnableSimilarShape bool `json:"enable_similar_shape,omitempty"` // 启用形近字检测（如：幾/几）
+	EnableVariantForm  bool `json:"enable_variant_form,omitempty"`  // 启用异体字检测（如：门/門）
+	EnableZhPYMix      bool `json:"enable_zh_py_mix,omitempty"`     // 启用中文拼音混合检测（如：fa票）
+	StripInvisible     bool `json:"strip_invisible,omitempty"`      // 删除零宽字符、软连字符、双向控制符等不可见字符
+	NormalizeUnicode   bool `json:"normalize_unicode,omitempty"`    // 按 NFKC 兼容分解折叠字符（如 𝐟、Ⓕ、ｆ）并删除附加符号
+	StripEmoji         bool `json:"strip_emoji,omitempty"`          // 删除 emoji
+	EnableLeetspeak    bool `json:"enable_leetspeak,omitempty"`     // 启用 leetspeak 和符号替换检测（如：f4ck、$hit）
+
+	Substitutions Substitutions `json:"substitutions,omitempty"` // 自定义替换字符表，在预处理之后生效，覆盖内置 leetspeak 表中的同名字符
+
+	ScoreAggregation ScoreAggregation `json:"score_aggregation,omitempty"` // 风险评分聚合方式，默认 max
+	ScoreDecay       float64          `json:"score_decay,omitempty"`       // decayed-sum 的衰减系数，取值 (0,1]，默认 0.5
+
+	Algorithm AlgorithmType `json:"algorithm,omitempty"` // 匹配算法，默认 aho-corasick
+	Allowlist []string      `json:"allowlist,omitempty"` // 白名单，落在白名单词条出现范围内的匹配会被忽略
+
+	Normalizers []Normalizer `json:"-"` // 在内置预处理之后依次执行的自定义标准化阶段
+	Stages      []Stage      `json:"-"` // 完整的预处理流水线，不为 nil 时取代由开关和 Normalizers 生成的阶段
+
+	Logger  *slog.Logger `json:"-"` // 结构化日志，为 nil 时使用 slog.Default()
+	OnError func(error)  `json:"-"` // 后台任务（如词库变更后重建自动机）失败时的回调
+}
+
+// LoggerOrDefault 返回配置的日志记录器，未配置时返回 slog.Default()
+func (o SWDOptions) LoggerOrDefault() *slog.Logger {
+	if o.Logger != nil {
+		return o.Logger
+	}
+	return slog.Default()
 }
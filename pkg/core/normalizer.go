@@ -0,0 +1,23 @@
+package core
+
+// Normalizer 文本标准化阶段，逐字符转换待检测的文本
+//
+// 实现需可并发调用。输出的每个字符都视为来自输入字符 r，检测报告据此映射回原文位置。
+type Normalizer interface {
+	// Normalize 将字符 r 转换后追加到 dst 并返回，不追加任何字符即删除 r
+	Normalize(dst []rune, r rune) []rune
+}
+
+// Stage 预处理流水线中命名的标准化阶段，名称用于插入和调整顺序
+type Stage struct {
+	Name       string
+	Normalizer Normalizer
+}
+
+// NormalizerFunc 以函数实现 Normalizer
+type NormalizerFunc func(dst []rune, r rune) []rune
+
+// Normalize 实现 Normalizer
+func (f NormalizerFunc) Normalize(dst []rune, r rune) []rune {
+	return f(dst, r)
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// ReportSchemaVersion 检测报告结构版本，字段发生不兼容变更时递增
+const ReportSchemaVersion = "1"
+
+// DetectionReport 结构化的检测报告，可直接序列化为 JSON 记录或转发
+//
+// 字段只使用字符串、数值、布尔值和列表，JSON 标签保持稳定，便于映射为 Protobuf 消息。
+// 报告不包含原文，只记录其哈希；匹配结果中的片段为命中部分的原文。
+type DetectionReport struct {
+	SchemaVersion string              `json:"schema_version"` // 报告结构版本，见 ReportSchemaVersion
+	InputHash     string              `json:"input_hash"`     // 原文的哈希，格式为 "sha256:<十六进制>"
+	InputLength   int                 `json:"input_length"`   // 原文长度（字符数）
+	Detected      bool                `json:"detected"`       // 是否包含敏感词
+	Matches       []ReportMatch       `json:"matches"`        // 匹配结果，按原文位置排序，无匹配时为空列表
+	Categories    []category.Category `json:"categories"`     // 所有匹配结果涉及的分类（去重），无匹配时为空列表
+	Severity      int                 `json:"severity"`       // 匹配结果中的最高严重程度，未配置时为 0
+	Score         float64             `json:"score"`          // 风险评分
+	DictVersion   uint64              `json:"dict_version"`   // 检测使用的词库版本，0 表示未知
+	Options       SWDOptions          `json:"options"`        // 检测使用的配置
+	StartedAt     time.Time           `json:"started_at"`     // 检测开始时间（RFC 3339）
+	DurationNanos int64               `json:"duration_ns"`    // 检测耗时（纳秒）
+}
+
+// ReportMatch 检测报告中的单个匹配结果
+//
+// 位置均指向原文（而非预处理后的文本），Start/End 为字符偏移，ByteStart/ByteEnd 为 UTF-8 字节偏移，均为左闭右开区间。
+type ReportMatch struct {
+	Word       string              `json:"word"`                 // 命中的词条
+	Text       string              `json:"text"`                 // 原文中对应的片段
+	Start      int                 `json:"start"`                // 起始字符偏移
+	End        int                 `json:"end"`                  // 结束字符偏移
+	ByteStart  int                 `json:"byte_start"`           // 起始字节偏移
+	ByteEnd    int                 `json:"byte_end"`             // 结束字节偏移
+	Category   category.Category   `json:"category"`             // 主分类
+	Categories []category.Category `json:"categories,omitempty"` // 属于多个分类时的全部分类
+	Severity   int                 `json:"severity"`             // 严重程度，未配置时为 0
+	Action     string              `json:"action,omitempty"`     // 词条配置的处置动作
+	Tags       []string            `json:"tags,omitempty"`       // 词条标签
+}
+
+// Reporter 可选接口，检测器实现后可生成结构化的检测报告
+type Reporter interface {
+	// Report 检测文本并生成检测报告
+	Report(text string) DetectionReport
+}
+
+// HashInput 计算报告中使用的原文哈希
+func HashInput(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Substitutions 替换字符表，键为文本中的字符，值为它可能代表的候选字符（如 '1' 代表 'i' 或 'l'）
+//
+// 检测时字符本身始终是候选之一，任一候选组合构成的词条都会命中。
+// JSON 形式以单个字符为键、候选字符组成的字符串为值，如 {"1": "il", "$": "s"}。
+type Substitutions map[rune][]rune
+
+// MarshalJSON 实现 json.Marshaler
+func (s Substitutions) MarshalJSON() ([]byte, error) {
+	m := make(map[string]string, len(s))
+	for r, candidates := range s {
+		m[string(r)] = string(candidates)
+	}
+	return json.Marshal(m)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，键必须是单个字符
+func (s *Substitutions) UnmarshalJSON(data []byte) error {
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	if m == nil {
+		*s = nil
+		return nil
+	}
+	table := make(Substitutions, len(m))
+	for key, candidates := range m {
+		r, size := utf8.DecodeRuneInString(key)
+		if size == 0 || size != len(key) {
+			return fmt.Errorf("invalid substitution key %q: must be a single character", key)
+		}
+		table[r] = []rune(candidates)
+	}
+	*s = table
+	return nil
+}
+
+// CandidateMatcher 可选的算法接口，在每个位置有多个候选字符的文本中匹配敏感词
+type CandidateMatcher interface {
+	// MatchAllCandidates 返回任一候选组合中出现的所有敏感词，位置按 text 的字符计
+	MatchAllCandidates(text string, subs Substitutions) []SensitiveWord
+}
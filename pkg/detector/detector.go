@@ -3,8 +3,10 @@ package detector
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ttofTnT/go-swd/pkg/algorithm"
 	"github.com/ttofTnT/go-swd/pkg/core"
@@ -16,15 +18,24 @@ import (
 // detector 实现敏感词检测器接口
 type detector struct {
 	algo       core.Algorithm
+	allow      core.Algorithm     // 白名单，没有白名单时为 nil
+	subs       core.Substitutions // 替换字符表，未启用替换检测时为 nil
 	preprocess *preprocessor.Preprocessor
+	meta       map[string]core.WordMeta
+	cats       map[string][]category.Category // 属于多个分类的词条
+	version    uint64                         // 当前词库版本
+	disabled   *category.Set                  // 已停用的分类
 	mu         sync.RWMutex
 	options    core.SWDOptions
+	inst       atomic.Pointer[core.Instrumentation] // 指标采集，未设置时为 nil
 }
 
 // NewDetector 创建一个新的检测器实例
 func NewDetector(options core.SWDOptions) (core.Detector, error) {
-	ahoCorasick := algorithm.NewAhoCorasick()
 	loader := dictionary.NewLoader()
+	if options.Logger != nil {
+		loader.SetLogger(options.Logger)
+	}
 
 	// 加载词典
 	if err := loader.LoadDefaultWords(context.Background()); err != nil {
@@ -32,121 +43,408 @@ func NewDetector(options core.SWDOptions) (core.Detector, error) {
 	}
 
 	// 获取词典内容
-	words := loader.GetWords()
-	if len(words) == 0 {
+	snapshot := loader.Snapshot()
+	if len(snapshot.Words) == 0 {
 		return nil, fmt.Errorf("词典内容为空")
 	}
 
+	d, err := NewDetectorWithWords(options, snapshot.Words, loader.Version())
+	if err != nil {
+		return nil, err
+	}
+	d.(*detector).OnMetaChanged(snapshot.Meta)
+	d.(*detector).OnCategoriesChanged(snapshot.Categories)
+
+	// 注册为观察者
+	loader.AddObserver(d.(core.Observer))
+
+	return d, nil
+}
+
+// NewDetectorWithWords 以给定词库创建检测器，算法和白名单只构建一次，需自行注册为加载器的观察者
+func NewDetectorWithWords(options core.SWDOptions, words map[string]category.Category, version uint64) (core.Detector, error) {
+	if err := validateAggregation(options.ScoreAggregation); err != nil {
+		return nil, err
+	}
+
+	algo, err := algorithm.New(options.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if setter, ok := algo.(core.LoggerSetter); ok && options.Logger != nil {
+		setter.SetLogger(options.Logger)
+	}
+
 	// 构建算法
-	if err := ahoCorasick.Build(words); err != nil {
+	if err := algo.Build(words); err != nil {
 		return nil, fmt.Errorf("构建算法失败: %w", err)
 	}
 
+	// 替换检测需要算法支持候选字符匹配
+	subs := preprocessor.SubstitutionTable(options)
+	if _, ok := algo.(core.CandidateMatcher); subs != nil && !ok {
+		return nil, fmt.Errorf("%w: %s does not support substitutions", algorithm.ErrUnsupported, algo.Type())
+	}
+
 	d := &detector{
-		algo:       ahoCorasick,
+		algo:       algo,
+		subs:       subs,
 		preprocess: preprocessor.NewPreprocessor(options),
 		options:    options,
+		version:    version,
+		disabled:   category.NewSet(),
 	}
 
-	// 注册为观察者
-	loader.AddObserver(d)
+	// 构建白名单
+	if len(options.Allowlist) > 0 {
+		allowed := make(map[string]category.Category, len(options.Allowlist))
+		for _, word := range options.Allowlist {
+			if word != "" {
+				allowed[word] = category.None
+			}
+		}
+		d.allow = algorithm.NewAhoCorasick()
+		if err := d.allow.Build(allowed); err != nil {
+			return nil, fmt.Errorf("构建白名单失败: %w", err)
+		}
+	}
 
 	return d, nil
 }
 
 // OnWordsChanged 实现Observer接口,当词库变更时重建算法
 func (d *detector) OnWordsChanged(words map[string]category.Category) {
+	d.mu.Lock()
+	err := d.rebuild(words, d.version)
+	d.mu.Unlock()
+
+	// 回调方法无法返回错误，在释放锁后交给错误回调
+	d.reportError(err)
+}
+
+// OnVersionChanged 实现VersionObserver接口,重建算法并记录词库版本
+func (d *detector) OnVersionChanged(version uint64, words map[string]category.Category) {
+	d.mu.Lock()
+	err := d.rebuild(words, version)
+	if err == nil {
+		// 重建失败时保留原版本号
+		d.version = version
+	}
+	d.mu.Unlock()
+
+	d.reportError(err)
+}
+
+// rebuild 重建算法，报告耗时并记录日志，调用方需持有写锁
+func (d *detector) rebuild(words map[string]category.Category, version uint64) error {
+	start := time.Now()
+	err := d.algo.Build(words)
+	elapsed := time.Since(start)
+	if inst := d.instrumentation(); inst != nil {
+		inst.ObserveRebuild(len(words), elapsed, err)
+	}
+
+	logger := d.options.LoggerOrDefault()
+	if err != nil {
+		logger.Error("failed to rebuild matcher",
+			"algorithm", d.algo.Type(), "words", len(words), "version", version, "error", err)
+		return fmt.Errorf("failed to rebuild %s for version %d: %w", d.algo.Type(), version, err)
+	}
+	logger.Debug("rebuilt matcher",
+		"algorithm", d.algo.Type(), "words", len(words), "version", version, "duration", elapsed)
+	return nil
+}
+
+// reportError 调用配置的错误回调，调用方不能持有锁
+func (d *detector) reportError(err error) {
+	if err != nil && d.options.OnError != nil {
+		d.options.OnError(err)
+	}
+}
+
+// SetLogger 实现 core.LoggerSetter 接口，设置重建时使用的日志记录器
+func (d *detector) SetLogger(logger *slog.Logger) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
+	d.options.Logger = logger
+	if setter, ok := d.algo.(core.LoggerSetter); ok {
+		setter.SetLogger(logger)
+	}
+}
 
-	// 重建算法
-	if err := d.algo.Build(words); err != nil {
-		// 这里只能记录错误,因为是回调方法
-		log.Printf("重建算法失败: %v", err)
+// SetInstrumentation 实现 Instrumented 接口，设置检测和重建的指标采集
+func (d *detector) SetInstrumentation(inst core.Instrumentation) {
+	if inst == nil {
+		d.inst.Store(nil)
+		return
 	}
+	d.inst.Store(&inst)
 }
 
-// Detect 检查文本是否包含任何敏感词
-func (d *detector) Detect(text string) bool {
-	if text == "" {
-		return false
+// instrumentation 返回当前的指标采集，未设置时为 nil
+func (d *detector) instrumentation() core.Instrumentation {
+	if p := d.inst.Load(); p != nil {
+		return *p
 	}
+	return nil
+}
 
-	// 预处理文本
-	processedText := d.preprocess.Process(text)
+// observeScan 向指标采集报告一次检测
+func (d *detector) observeScan(op string, start time.Time, matches []core.SensitiveWord) {
+	if inst := d.instrumentation(); inst != nil {
+		inst.ObserveScan(op, matches, time.Since(start))
+	}
+}
 
-	// 使用读锁进行检测
-	d.mu.RLock()
-	match := d.algo.Match(processedText)
-	d.mu.RUnlock()
+// observeFirst 向指标采集报告一次只返回第一个匹配结果的检测
+func (d *detector) observeFirst(op string, start time.Time, match *core.SensitiveWord) {
+	inst := d.instrumentation()
+	if inst == nil {
+		return
+	}
+	var matches []core.SensitiveWord
+	if match != nil {
+		matches = []core.SensitiveWord{*match}
+	}
+	inst.ObserveScan(op, matches, time.Since(start))
+}
 
-	return match != nil
+// OnMetaChanged 实现MetaObserver接口,当词条元数据变更时更新
+func (d *detector) OnMetaChanged(meta map[string]core.WordMeta) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.meta = meta
 }
 
-// DetectIn 检查文本是否包含指定分类的敏感词
-func (d *detector) DetectIn(text string, categories ...category.Category) bool {
-	if text == "" || len(categories) == 0 {
-		return false
+// OnCategoriesChanged 实现CategoriesObserver接口,当多分类词条变更时更新
+func (d *detector) OnCategoriesChanged(categories map[string][]category.Category) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.cats = categories
+}
+
+// annotate 为匹配结果附加词条元数据和词库版本，调用方需持有读锁
+func (d *detector) annotate(matches []core.SensitiveWord) {
+	for i := range matches {
+		d.annotateWord(&matches[i])
 	}
+}
 
-	// 预处理文本
-	processedText := d.preprocess.Process(text)
+// annotateWord 为单个匹配结果附加元数据、全部分类和词库版本，调用方需持有读锁
+func (d *detector) annotateWord(match *core.SensitiveWord) {
+	match.DictVersion = d.version
+	if cats, ok := d.cats[match.Word]; ok {
+		match.Categories = cats
+	}
+	if meta, ok := d.meta[match.Word]; ok {
+		match.Meta = &meta
+	}
+}
+
+// EnableCategories 重新启用指定分类的检测
+func (d *detector) EnableCategories(categories ...category.Category) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, cat := range categories {
+		d.disabled.Remove(cat)
+	}
+}
+
+// DisableCategories 停用指定分类的检测，词库中的词条保持不变
+func (d *detector) DisableCategories(categories ...category.Category) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, cat := range categories {
+		d.disabled.Add(cat)
+	}
+}
+
+// DisabledCategories 返回已停用分类的副本
+func (d *detector) DisabledCategories() *category.Set {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.disabled.Clone()
+}
+
+// hasDisabled 判断是否有停用的分类
+func (d *detector) hasDisabled() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return !d.disabled.IsEmpty()
+}
+
+// collect 返回文本中所有启用分类的匹配结果，位置为原文中的字符区间
+func (d *detector) collect(text string) []core.SensitiveWord {
+	// 预处理文本，预处理可能增删字符，匹配位置需映射回原文
+	processedText, offsets := d.preprocess.ProcessWithOffsets(text)
 
 	// 使用读锁进行检测
 	d.mu.RLock()
-	matches := d.algo.MatchAll(processedText)
+	matches := d.collectProcessed(processedText)
 	d.mu.RUnlock()
 
-	// 检查是否有任何匹配的分类
+	preprocessor.ToOriginal(matches, offsets)
+	return matches
+}
+
+// collectProcessed 返回已预处理文本中所有启用分类的匹配结果，调用方需持有读锁
+func (d *detector) collectProcessed(processedText string) []core.SensitiveWord {
+	var matches []core.SensitiveWord
+	if d.subs != nil {
+		matches = d.algo.(core.CandidateMatcher).MatchAllCandidates(processedText, d.subs)
+	} else {
+		matches = d.algo.MatchAll(processedText)
+	}
+	if d.allow != nil && len(matches) > 0 {
+		matches = dropAllowed(matches, d.allow.MatchAll(processedText))
+	}
+	d.annotate(matches)
+	if !d.disabled.IsEmpty() {
+		enabled := matches[:0]
+		for _, match := range matches {
+			if !d.isDisabled(match) {
+				enabled = append(enabled, match)
+			}
+		}
+		matches = enabled
+	}
+	return matches
+}
+
+// dropAllowed 剔除落在某个白名单词条出现范围内的匹配
+func dropAllowed(matches, allowed []core.SensitiveWord) []core.SensitiveWord {
+	if len(allowed) == 0 {
+		return matches
+	}
+	result := matches[:0]
 	for _, match := range matches {
-		for _, cat := range categories {
-			if cat.Contains(match.Category) {
-				return true
+		covered := false
+		for _, a := range allowed {
+			if a.StartPos <= match.StartPos && match.EndPos <= a.EndPos {
+				covered = true
+				break
 			}
 		}
+		if !covered {
+			result = append(result, match)
+		}
+	}
+	return result
+}
+
+// isDisabled 判断匹配结果的分类是否全部停用，调用方需持有读锁
+func (d *detector) isDisabled(match core.SensitiveWord) bool {
+	for _, cat := range match.AllCategories() {
+		if !d.disabled.Covers(cat) {
+			return false
+		}
+	}
+	return true
+}
+
+// inCategories 判断匹配结果的任一分类是否属于任一指定分类
+func inCategories(match core.SensitiveWord, categories []category.Category) bool {
+	return match.In(categories...)
+}
+
+// selectMatches 筛选属于（include 为 true）或不属于指定分类的匹配结果
+func selectMatches(matches []core.SensitiveWord, categories []category.Category, include bool) []core.SensitiveWord {
+	var result []core.SensitiveWord
+	for _, match := range matches {
+		if inCategories(match, categories) == include {
+			result = append(result, match)
+		}
 	}
+	return result
+}
+
+// Detect 检查文本是否包含任何敏感词
+func (d *detector) Detect(text string) bool {
+	start := time.Now()
+	match := d.first(text)
+	d.observeFirst("Detect", start, match)
+	return match != nil
+}
+
+// DetectIn 检查文本是否包含指定分类的敏感词
+func (d *detector) DetectIn(text string, categories ...category.Category) bool {
+	start := time.Now()
+	match := d.firstIn(text, categories, true)
+	d.observeFirst("DetectIn", start, match)
+	return match != nil
+}
 
-	return false
+// DetectNotIn 检查文本是否包含指定分类以外的敏感词
+func (d *detector) DetectNotIn(text string, categories ...category.Category) bool {
+	start := time.Now()
+	match := d.firstIn(text, categories, false)
+	d.observeFirst("DetectNotIn", start, match)
+	return match != nil
 }
 
 // Match 返回文本中找到的第一个敏感词
 func (d *detector) Match(text string) *core.SensitiveWord {
+	start := time.Now()
+	match := d.first(text)
+	d.observeFirst("Match", start, match)
+	return match
+}
+
+// first 返回文本中找到的第一个敏感词
+func (d *detector) first(text string) *core.SensitiveWord {
 	if text == "" {
 		return nil
 	}
+	if d.allow != nil || d.subs != nil || d.hasDisabled() {
+		if matches := d.collect(text); len(matches) > 0 {
+			return &matches[0]
+		}
+		return nil
+	}
 
 	// 预处理文本
-	processedText := d.preprocess.Process(text)
+	processedText, offsets := d.preprocess.ProcessWithOffsets(text)
 
 	// 使用读锁进行检测
 	d.mu.RLock()
 	match := d.algo.Match(processedText)
+	if match != nil {
+		d.annotateWord(match)
+	}
 	d.mu.RUnlock()
 
+	if match != nil {
+		match.StartPos, match.EndPos = preprocessor.OriginalSpan(offsets, match.StartPos, match.EndPos)
+	}
 	return match
 }
 
 // MatchIn 返回文本中找到的第一个指定分类的敏感词
 func (d *detector) MatchIn(text string, categories ...category.Category) *core.SensitiveWord {
-	if text == "" || len(categories) == 0 {
-		return nil
-	}
+	start := time.Now()
+	match := d.firstIn(text, categories, true)
+	d.observeFirst("MatchIn", start, match)
+	return match
+}
 
-	// 预处理文本
-	processedText := d.preprocess.Process(text)
+// MatchNotIn 返回文本中找到的第一个指定分类以外的敏感词
+func (d *detector) MatchNotIn(text string, categories ...category.Category) *core.SensitiveWord {
+	start := time.Now()
+	match := d.firstIn(text, categories, false)
+	d.observeFirst("MatchNotIn", start, match)
+	return match
+}
 
-	// 使用读锁进行检测
-	d.mu.RLock()
-	matches := d.algo.MatchAll(processedText)
-	d.mu.RUnlock()
+// firstIn 返回文本中第一个属于（include 为 true）或不属于指定分类的敏感词
+func (d *detector) firstIn(text string, categories []category.Category, include bool) *core.SensitiveWord {
+	if text == "" || (include && len(categories) == 0) {
+		return nil
+	}
 
-	// 返回第一个匹配的分类
-	for _, match := range matches {
-		for _, cat := range categories {
-			if cat.Contains(match.Category) {
-				result := match
-				return &result
-			}
+	for _, match := range d.collect(text) {
+		if inCategories(match, categories) == include {
+			result := match
+			return &result
 		}
 	}
 
@@ -155,45 +453,50 @@ func (d *detector) MatchIn(text string, categories ...category.Category) *core.S
 
 // MatchAll 返回文本中找到的所有敏感词
 func (d *detector) MatchAll(text string) []core.SensitiveWord {
-	if text == "" {
-		return nil
-	}
-
-	// 预处理文本
-	processedText := d.preprocess.Process(text)
-
-	// 使用读锁进行检测
-	d.mu.RLock()
-	matches := d.algo.MatchAll(processedText)
-	d.mu.RUnlock()
-
+	start := time.Now()
+	matches := d.matchAll(text)
+	d.observeScan("MatchAll", start, matches)
 	return matches
 }
 
 // MatchAllIn 返回文本中找到的所有指定分类的敏感词
 func (d *detector) MatchAllIn(text string, categories ...category.Category) []core.SensitiveWord {
-	if text == "" || len(categories) == 0 {
-		return nil
+	start := time.Now()
+	var matches []core.SensitiveWord
+	if len(categories) > 0 {
+		matches = selectMatches(d.matchAll(text), categories, true)
 	}
+	d.observeScan("MatchAllIn", start, matches)
+	return matches
+}
 
-	// 预处理文本
-	processedText := d.preprocess.Process(text)
-
-	// 使用读锁进行检测
-	d.mu.RLock()
-	allMatches := d.algo.MatchAll(processedText)
-	d.mu.RUnlock()
+// MatchAllNotIn 返回文本中找到的所有指定分类以外的敏感词
+func (d *detector) MatchAllNotIn(text string, categories ...category.Category) []core.SensitiveWord {
+	start := time.Now()
+	matches := selectMatches(d.matchAll(text), categories, false)
+	d.observeScan("MatchAllNotIn", start, matches)
+	return matches
+}
 
-	// 过滤出指定分类的敏感词
-	var matches []core.SensitiveWord
-	for _, match := range allMatches {
-		for _, cat := range categories {
-			if cat.Contains(match.Category) {
-				matches = append(matches, match)
-				break // 避免同一个敏感词被多个分类匹配而重复添加
-			}
-		}
+// matchAll 返回文本中找到的所有敏感词，不报告指标
+func (d *detector) matchAll(text string) []core.SensitiveWord {
+	if text == "" {
+		return nil
 	}
+	return d.collect(text)
+}
 
-	return matches
+// Score 计算文本的风险评分
+func (d *detector) Score(text string) core.RiskScore {
+	start := time.Now()
+	matches := d.matchAll(text)
+	d.observeScan("Score", start, matches)
+	return ScoreMatches(matches, d.currentOptions())
+}
+
+// currentOptions 在读锁内复制检测选项，SetLogger 会并发修改其中的 Logger
+func (d *detector) currentOptions() core.SWDOptions {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.options
 }
@@ -1,6 +1,9 @@
 package detector
 
 import (
+	"errors"
+	"io"
+	"log/slog"
 	"testing"
 
 	"github.com/ttofTnT/go-swd/pkg/core"
@@ -579,3 +582,229 @@ func TestDetector_DetectIn_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestDetector_WordMeta(t *testing.T) {
+	d, err := NewDetector(core.SWDOptions{})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+
+	observer := d.(*detector)
+	observer.OnWordsChanged(map[string]category.Category{
+		"赌场": category.Gambling,
+		"骗子": category.Scam,
+	})
+	observer.OnMetaChanged(map[string]core.WordMeta{
+		"赌场": {Severity: 3, Action: "block"},
+	})
+
+	matches := d.MatchAll("这里有赌场和骗子")
+	if len(matches) != 2 {
+		t.Fatalf("MatchAll() 返回长度 = %d, 期望长度 2", len(matches))
+	}
+	if matches[0].Meta == nil || matches[0].Meta.Severity != 3 || matches[0].Meta.Action != "block" {
+		t.Errorf("MatchAll()[0].Meta = %+v, 期望 severity=3 action=block", matches[0].Meta)
+	}
+	if matches[1].Meta != nil {
+		t.Errorf("MatchAll()[1].Meta = %+v, 期望 nil", matches[1].Meta)
+	}
+
+	if match := d.Match("赌场"); match == nil || match.Meta == nil {
+		t.Errorf("Match() 未附加元数据: %+v", match)
+	}
+}
+
+func TestDetector_DictVersion(t *testing.T) {
+	d, err := NewDetector(core.SWDOptions{})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+
+	d.(*detector).OnVersionChanged(7, map[string]category.Category{
+		"赌场": category.Gambling,
+	})
+
+	if match := d.Match("这里有赌场"); match == nil || match.DictVersion != 7 {
+		t.Errorf("Match().DictVersion = %+v, 期望 7", match)
+	}
+	matches := d.MatchAll("赌场赌场")
+	if len(matches) == 0 || matches[0].DictVersion != 7 {
+		t.Errorf("MatchAll() = %+v, 期望 DictVersion 为 7", matches)
+	}
+}
+
+func TestDetector_CategoryToggle(t *testing.T) {
+	d, err := NewDetector(core.SWDOptions{})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+	d.(core.Observer).OnWordsChanged(map[string]category.Category{
+		"赌场": category.Gambling,
+		"傻瓜": category.Profanity,
+	})
+	toggler := d.(core.CategoryToggler)
+	text := "傻瓜去赌场"
+
+	// 排除指定分类
+	if got := d.MatchAllNotIn(text, category.Profanity); len(got) != 1 || got[0].Word != "赌场" {
+		t.Errorf("MatchAllNotIn() = %+v, 期望仅包含 赌场", got)
+	}
+	if d.DetectNotIn("傻瓜", category.Profanity) {
+		t.Error("DetectNotIn() 未排除脏话分类")
+	}
+	if match := d.MatchNotIn(text, category.Gambling); match == nil || match.Word != "傻瓜" {
+		t.Errorf("MatchNotIn() = %+v, 期望 傻瓜", match)
+	}
+
+	// 停用分类后所有检测方法均不再返回该分类
+	toggler.DisableCategories(category.Profanity)
+	if got := toggler.DisabledCategories().Categories(); len(got) != 1 || got[0] != category.Profanity {
+		t.Errorf("DisabledCategories() = %v, 期望 [%v]", got, category.Profanity)
+	}
+	if d.Detect("傻瓜") || d.Match("傻瓜") != nil || d.DetectIn("傻瓜", category.Profanity) {
+		t.Error("停用的分类仍被检测到")
+	}
+	if got := d.MatchAll(text); len(got) != 1 || got[0].Word != "赌场" {
+		t.Errorf("MatchAll() = %+v, 期望仅包含 赌场", got)
+	}
+
+	// 重新启用后立即生效
+	toggler.EnableCategories(category.Profanity)
+	if !d.Detect("傻瓜") || len(d.MatchAll(text)) != 2 {
+		t.Error("重新启用的分类未被检测到")
+	}
+}
+
+func TestDetector_MultiCategory(t *testing.T) {
+	d, err := NewDetector(core.SWDOptions{})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+	d.(core.Observer).OnWordsChanged(map[string]category.Category{"骗子": category.Gambling})
+	d.(core.CategoriesObserver).OnCategoriesChanged(map[string][]category.Category{
+		"骗子": {category.Gambling, category.Scam},
+	})
+
+	// 按任一分类均可匹配
+	match := d.MatchIn("他是骗子", category.Scam)
+	if match == nil || len(match.Categories) != 2 {
+		t.Fatalf("MatchIn(Scam) = %+v, 期望匹配 骗子 并附带全部分类", match)
+	}
+	if !d.DetectIn("他是骗子", category.Gambling) || d.DetectIn("他是骗子", category.Political) {
+		t.Error("DetectIn() 分类过滤结果错误")
+	}
+	if d.DetectNotIn("他是骗子", category.Scam) {
+		t.Error("DetectNotIn() 应排除属于任一指定分类的词条")
+	}
+
+	// 仅当全部分类均停用时才不再检测
+	toggler := d.(core.CategoryToggler)
+	toggler.DisableCategories(category.Gambling)
+	if !d.Detect("他是骗子") {
+		t.Error("仍有启用的分类时应检测到")
+	}
+	toggler.DisableCategories(category.Scam)
+	if d.Detect("他是骗子") {
+		t.Error("全部分类停用后仍被检测到")
+	}
+}
+
+func TestDetector_SubCategory(t *testing.T) {
+	d, err := NewDetector(core.SWDOptions{})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+	leaders := category.RegisterSubCategory(category.Political, "测试领导人")
+	defer func() { _ = category.UnregisterCategory("涉政/测试领导人") }()
+	if got := leaders.String(); got != "涉政/测试领导人" {
+		t.Errorf("String() = %q, 期望 %q", got, "涉政/测试领导人")
+	}
+
+	d.(core.Observer).OnWordsChanged(map[string]category.Category{
+		"某领导": leaders,
+		"赌场":  category.Gambling,
+	})
+	text := "某领导去赌场"
+
+	// 按父分类查询时包含子分类
+	if got := d.MatchAllIn(text, category.Political); len(got) != 1 || got[0].Word != "某领导" {
+		t.Errorf("MatchAllIn(涉政) = %+v, 期望仅包含 某领导", got)
+	}
+	if !d.DetectIn("某领导", category.Political) || !d.DetectIn("某领导", leaders) {
+		t.Error("DetectIn() 未按层级匹配")
+	}
+
+	// 停用父分类时子分类一并停用
+	d.(core.CategoryToggler).DisableCategories(category.Political)
+	if d.Detect("某领导") {
+		t.Error("停用父分类后子分类仍被检测到")
+	}
+}
+
+// failingAlgorithm 重建总是失败的算法
+type failingAlgorithm struct {
+	core.Algorithm
+}
+
+func (failingAlgorithm) Build(map[string]category.Category) error {
+	return errors.New("build failed")
+}
+
+func TestDetector_RebuildError(t *testing.T) {
+	var errs []error
+	d, err := NewDetector(core.SWDOptions{
+		Logger:  slog.New(slog.NewTextHandler(io.Discard, nil)),
+		OnError: func(err error) { errs = append(errs, err) },
+	})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+	det := d.(*detector)
+	version := det.version
+	det.algo = failingAlgorithm{Algorithm: det.algo}
+
+	det.OnVersionChanged(version+1, map[string]category.Category{"赌场": category.Gambling})
+	if len(errs) != 1 {
+		t.Fatalf("错误回调次数 = %d, 期望 1", len(errs))
+	}
+	if det.version != version {
+		t.Errorf("重建失败后版本号 = %d, 期望保留 %d", det.version, version)
+	}
+
+	det.OnWordsChanged(map[string]category.Category{"赌场": category.Gambling})
+	if len(errs) != 2 {
+		t.Errorf("错误回调次数 = %d, 期望 2", len(errs))
+	}
+}
+
+func TestNewDetectorWithWords(t *testing.T) {
+	words := map[string]category.Category{"赌场": category.Gambling, "赌": category.Gambling}
+	d, err := NewDetectorWithWords(core.SWDOptions{
+		Algorithm: core.AlgorithmTrie,
+		Allowlist: []string{"赌场风云"},
+	}, words, 3)
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+	if got := d.(*detector).algo.Type(); got != core.AlgorithmTrie {
+		t.Errorf("算法 = %v, 期望 %v", got, core.AlgorithmTrie)
+	}
+
+	// 白名单范围内的所有匹配都被忽略
+	if d.Detect("赌场风云") {
+		t.Error("白名单范围内的匹配应被忽略")
+	}
+	matches := d.MatchAll("赌场风云和赌场")
+	if len(matches) == 0 {
+		t.Fatal("白名单范围外的匹配不应被忽略")
+	}
+	for _, match := range matches {
+		if match.StartPos < 5 || match.DictVersion != 3 {
+			t.Errorf("MatchAll() = %+v, 期望只返回白名单范围外的匹配", matches)
+		}
+	}
+
+	if _, err := NewDetectorWithWords(core.SWDOptions{Algorithm: core.AlgorithmDFA}, words, 0); err == nil {
+		t.Error("不支持的算法应返回错误")
+	}
+}
@@ -0,0 +1,147 @@
+package preprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+)
+
+var (
+	// ErrStageNotFound 流水线中没有指定名称的阶段
+	ErrStageNotFound = errors.New("stage not found")
+	// ErrDuplicateStage 流水线中已有同名阶段
+	ErrDuplicateStage = errors.New("duplicate stage")
+	// ErrInvalidStage 阶段没有名称或标准化实现
+	ErrInvalidStage = errors.New("invalid stage")
+)
+
+// Pipeline 按顺序执行的标准化阶段，用于组装 SWDOptions.Stages
+//
+// Pipeline 不是并发安全的，应在创建检测器之前编辑完成。
+type Pipeline struct {
+	stages []core.Stage
+}
+
+// NewPipeline 以给定阶段创建流水线
+func NewPipeline(stages ...core.Stage) (*Pipeline, error) {
+	p := &Pipeline{}
+	for _, stage := range stages {
+		if err := p.Append(stage); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// DefaultPipeline 按检测选项的开关生成内置阶段，并在末尾追加 options.Normalizers
+//
+// 自定义阶段依次命名为 custom-1、custom-2 等。options.Stages 不为空时直接使用它。
+func DefaultPipeline(options core.SWDOptions) *Pipeline {
+	if options.Stages != nil {
+		return &Pipeline{stages: append([]core.Stage(nil), options.Stages...)}
+	}
+	p := &Pipeline{}
+	for _, name := range builtinOrder {
+		if enabled(name, options) {
+			p.stages = append(p.stages, core.Stage{Name: name, Normalizer: builtins[name]})
+		}
+	}
+	for i, n := range options.Normalizers {
+		p.stages = append(p.stages, core.Stage{Name: fmt.Sprintf("custom-%d", i+1), Normalizer: n})
+	}
+	return p
+}
+
+// Stages 返回流水线中的阶段
+func (p *Pipeline) Stages() []core.Stage {
+	return append([]core.Stage(nil), p.stages...)
+}
+
+// Names 按顺序返回阶段名称
+func (p *Pipeline) Names() []string {
+	names := make([]string, len(p.stages))
+	for i, stage := range p.stages {
+		names[i] = stage.Name
+	}
+	return names
+}
+
+// index 返回指定名称的阶段的位置，不存在时返回 -1
+func (p *Pipeline) index(name string) int {
+	for i, stage := range p.stages {
+		if stage.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// insert 校验后在指定位置插入阶段
+func (p *Pipeline) insert(i int, stage core.Stage) error {
+	if stage.Name == "" || stage.Normalizer == nil {
+		return fmt.Errorf("%w: %q", ErrInvalidStage, stage.Name)
+	}
+	if p.index(stage.Name) >= 0 {
+		return fmt.Errorf("%w: %q", ErrDuplicateStage, stage.Name)
+	}
+	p.stages = append(p.stages, core.Stage{})
+	copy(p.stages[i+1:], p.stages[i:])
+	p.stages[i] = stage
+	return nil
+}
+
+// Append 在末尾添加阶段
+func (p *Pipeline) Append(stage core.Stage) error {
+	return p.insert(len(p.stages), stage)
+}
+
+// InsertBefore 在指定阶段之前插入阶段
+func (p *Pipeline) InsertBefore(name string, stage core.Stage) error {
+	i := p.index(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrStageNotFound, name)
+	}
+	return p.insert(i, stage)
+}
+
+// InsertAfter 在指定阶段之后插入阶段
+func (p *Pipeline) InsertAfter(name string, stage core.Stage) error {
+	i := p.index(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrStageNotFound, name)
+	}
+	return p.insert(i+1, stage)
+}
+
+// Remove 移除指定阶段
+func (p *Pipeline) Remove(name string) error {
+	i := p.index(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrStageNotFound, name)
+	}
+	p.stages = append(p.stages[:i], p.stages[i+1:]...)
+	return nil
+}
+
+// Reorder 按给定名称重新排列阶段，names 须恰好包含流水线中的所有阶段
+func (p *Pipeline) Reorder(names ...string) error {
+	if len(names) != len(p.stages) {
+		return fmt.Errorf("%w: reorder needs all %d stages, got %d", ErrInvalidStage, len(p.stages), len(names))
+	}
+	reordered := make([]core.Stage, 0, len(names))
+	for _, name := range names {
+		i := p.index(name)
+		if i < 0 {
+			return fmt.Errorf("%w: %q", ErrStageNotFound, name)
+		}
+		for _, stage := range reordered {
+			if stage.Name == name {
+				return fmt.Errorf("%w: %q", ErrDuplicateStage, name)
+			}
+		}
+		reordered = append(reordered, p.stages[i])
+	}
+	p.stages = reordered
+	return nil
+}
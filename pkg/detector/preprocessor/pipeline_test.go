@@ -0,0 +1,117 @@
+package preprocessor
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+)
+
+// dropX 删除小写字母 x 的自定义阶段
+var dropX = core.Stage{Name: "drop-x", Normalizer: core.NormalizerFunc(func(dst []rune, r rune) []rune {
+	if r == 'x' {
+		return dst
+	}
+	return append(dst, r)
+})}
+
+func TestDefaultPipeline(t *testing.T) {
+	p := DefaultPipeline(core.SWDOptions{
+		IgnoreCase:        true,
+		IgnoreWidth:       true,
+		StripInvisible:    true,
+		NormalizeUnicode:  true,
+		EnableVariantForm: true,
+		Normalizers:       []core.Normalizer{dropX.Normalizer},
+	})
+	want := []string{StageInvisible, StageNFKC, StageCase, StageWidth, StageTraditional, "custom-1"}
+	if got := p.Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, 期望 %v", got, want)
+	}
+
+	// 指定了完整流水线时直接使用
+	stage, _ := NewStage(StageCase)
+	p = DefaultPipeline(core.SWDOptions{IgnoreWidth: true, Stages: []core.Stage{stage}})
+	if got := p.Names(); !reflect.DeepEqual(got, []string{StageCase}) {
+		t.Errorf("Names() = %v, 期望 [case]", got)
+	}
+}
+
+func TestPipeline_Edit(t *testing.T) {
+	p := DefaultPipeline(core.SWDOptions{IgnoreCase: true, IgnoreWidth: true, SkipWhitespace: true})
+	if err := p.InsertAfter(StageCase, dropX); err != nil {
+		t.Fatalf("InsertAfter() 错误 = %v", err)
+	}
+	if err := p.Remove(StageWhitespace); err != nil {
+		t.Fatalf("Remove() 错误 = %v", err)
+	}
+	if got, want := p.Names(), []string{StageCase, "drop-x", StageWidth}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, 期望 %v", got, want)
+	}
+
+	// 大小写阶段在前时大写 X 也会被删除
+	options := core.SWDOptions{Stages: p.Stages()}
+	if got := NewPreprocessor(options).Process("XxA B"); got != "a b" {
+		t.Errorf("Process() = %q, 期望 \"a b\"", got)
+	}
+	if err := p.Reorder("drop-x", StageWidth, StageCase); err != nil {
+		t.Fatalf("Reorder() 错误 = %v", err)
+	}
+	options.Stages = p.Stages()
+	if got := NewPreprocessor(options).Process("XxA B"); got != "xa b" {
+		t.Errorf("调整顺序后 Process() = %q, 期望 \"xa b\"", got)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"重复阶段", p.Append(dropX), ErrDuplicateStage},
+		{"空阶段", p.Append(core.Stage{Name: "empty"}), ErrInvalidStage},
+		{"插入位置不存在", p.InsertBefore("missing", core.Stage{Name: "a", Normalizer: dropX.Normalizer}), ErrStageNotFound},
+		{"移除不存在的阶段", p.Remove("missing"), ErrStageNotFound},
+		{"调整顺序缺少阶段", p.Reorder(StageCase), ErrInvalidStage},
+		{"调整顺序重复阶段", p.Reorder(StageCase, StageCase, StageWidth), ErrDuplicateStage},
+		{"未知内置阶段", func() error { _, err := NewStage("soundex"); return err }(), ErrStageNotFound},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: 错误 = %v, 期望 %v", tt.name, tt.err, tt.want)
+		}
+	}
+	if got := p.Names(); len(got) != 3 {
+		t.Errorf("出错的编辑不应修改流水线: %v", got)
+	}
+}
+
+func TestBuiltinStages(t *testing.T) {
+	tests := []struct {
+		stage string
+		text  string
+		want  string
+	}{
+		{StageInvisible, "赌\u200b博\ufeff\u00ad\u202e\u0007\ufe0f", "赌博"},
+		{StageNFKC, "\U0001d41f\U0001d42eⓒｋ f\u0301é ﬁ ⑴ が", "fuck fe fi 1 が"},
+		{StageEmoji, "赌😀博👍🏻❤️🇨🇳", "赌博"},
+		{StageConfusables, "fuсk", "fuck"}, // 西里尔字母 с
+		{StageTraditional, "賭場開門", "赌场开门"},
+		{StageNumbers, "①二３", "123"},
+		{StageWidth, "ｆｕｃｋ", "fuck"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.stage, func(t *testing.T) {
+			stage, err := NewStage(tt.stage)
+			if err != nil {
+				t.Fatalf("NewStage() 错误 = %v", err)
+			}
+			if got := NewPreprocessor(core.SWDOptions{Stages: []core.Stage{stage}}).Process(tt.text); got != tt.want {
+				t.Errorf("Process(%q) = %q, 期望 %q", tt.text, got, tt.want)
+			}
+		})
+	}
+	if got := BuiltinStages(); len(got) != len(builtins) {
+		t.Errorf("BuiltinStages() = %v, 数量应为 %d", got, len(builtins))
+	}
+}
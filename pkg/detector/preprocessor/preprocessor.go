@@ -1,21 +1,25 @@
 package preprocessor
 
 import (
-	"unicode"
+	"sync"
+	"unicode/utf8"
 
 	"github.com/ttofTnT/go-swd/pkg/core"
 )
 
-// Preprocessor 文本预处理器
+// Preprocessor 文本预处理器，按顺序执行标准化阶段
 type Preprocessor struct {
-	options core.SWDOptions
+	stages []core.Normalizer
 }
 
-// NewPreprocessor 创建新的预处理器实例
+// NewPreprocessor 创建新的预处理器实例，阶段由 DefaultPipeline 根据检测选项生成
 func NewPreprocessor(options core.SWDOptions) *Preprocessor {
-	return &Preprocessor{
-		options: options,
+	stages := DefaultPipeline(options).stages
+	p := &Preprocessor{stages: make([]core.Normalizer, len(stages))}
+	for i, stage := range stages {
+		p.stages[i] = stage.Normalizer
 	}
+	return p
 }
 
 // Process 处理文本
@@ -23,88 +27,111 @@ func (p *Preprocessor) Process(text string) string {
 	if text == "" {
 		return text
 	}
+	result, _ := p.process(text, false)
+	return result
+}
 
-	// 转换为rune切片以正确处理Unicode字符
-	runes := []rune(text)
-	result := make([]rune, 0, len(runes))
+// ProcessWithOffsets 处理文本，并返回处理后每个字符在原文中的字符偏移
+func (p *Preprocessor) ProcessWithOffsets(text string) (string, []int) {
+	if text == "" {
+		return text, nil
+	}
+	return p.process(text, true)
+}
 
-	for i := 0; i < len(runes); i++ {
-		r := runes[i]
+// OriginalSpan 将处理后文本中的字符区间 [start, end) 映射为原文中的字符区间
+//
+// offsets 为 ProcessWithOffsets 的返回值，为 nil 时认为位置未变化；区间无效时返回 -1, -1。
+func OriginalSpan(offsets []int, start, end int) (int, int) {
+	if offsets == nil {
+		return start, end
+	}
+	if start < 0 || end > len(offsets) || start >= end {
+		return -1, -1
+	}
+	return offsets[start], offsets[end-1] + 1
+}
+
+// ToOriginal 将匹配结果的位置从处理后的文本映射回原文
+func ToOriginal(matches []core.SensitiveWord, offsets []int) {
+	for i := range matches {
+		matches[i].StartPos, matches[i].EndPos = OriginalSpan(offsets, matches[i].StartPos, matches[i].EndPos)
+	}
+}
+
+// maxPooledBuffer 放回缓冲池的缓冲区容量上限（字符数），避免个别超长文本长期占用内存
+const maxPooledBuffer = 64 << 10
+
+// bufferPool 复用预处理结果的字符缓冲区，批量和并发检测时减少分配
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new([]rune) },
+}
 
-		// 忽略大小写
-		if p.options.IgnoreCase {
-			r = unicode.ToLower(r)
+// process 处理文本，withOffsets 为 true 时记录字符偏移
+func (p *Preprocessor) process(text string, withOffsets bool) (string, []int) {
+	// 没有任何阶段时原文即结果
+	if len(p.stages) == 0 && utf8.ValidString(text) {
+		if !withOffsets {
+			return text, nil
 		}
+		offsets := make([]int, utf8.RuneCountInString(text))
+		for i := range offsets {
+			offsets[i] = i
+		}
+		return text, offsets
+	}
 
-		// 忽略空白字符
-		if p.options.SkipWhitespace && unicode.IsSpace(r) {
-			continue
+	buf := bufferPool.Get().(*[]rune)
+	result := (*buf)[:0]
+	defer func() {
+		if cap(result) <= maxPooledBuffer {
+			*buf = result[:0]
+			bufferPool.Put(buf)
 		}
+	}()
+
+	var offsets []int
+	if withOffsets {
+		offsets = make([]int, 0, utf8.RuneCountInString(text))
+	}
+
+	// 各阶段的输入输出缓冲区，在字符之间复用
+	var stage, next []rune
+
+	changed := false
+	i := -1
+	for _, orig := range text {
+		i++
 
-		// 全角转半角
-		if p.options.IgnoreWidth {
-			if r > 0xFF00 && r < 0xFF5F {
-				r = r - 0xFEE0
+		// 每个阶段处理上一阶段输出的所有字符
+		stage = append(stage[:0], orig)
+		for _, n := range p.stages {
+			next = next[:0]
+			for _, c := range stage {
+				next = n.Normalize(next, c)
 			}
+			stage, next = next, stage
 		}
 
-		// 数字样式统一
-		if p.options.IgnoreNumStyle && (unicode.IsNumber(r) || isChineseNumber(r)) {
-			r = p.normalizeNumber(r)
+		if len(stage) != 1 || stage[0] != orig {
+			changed = true
+		}
+		result = append(result, stage...)
+		if withOffsets {
+			for range stage {
+				offsets = append(offsets, i)
+			}
 		}
-
-		result = append(result, r)
 	}
 
-	return string(result)
-}
-
-// isChineseNumber 判断是否是中文数字
-func isChineseNumber(r rune) bool {
-	chineseNumbers := map[rune]bool{
-		'零': true, '〇': true,
-		'一': true, '二': true, '三': true, '四': true, '五': true,
-		'六': true, '七': true, '八': true, '九': true, '十': true,
+	// 没有任何字符变化时直接返回原文，省去一次字符串分配
+	if !changed && utf8.ValidString(text) {
+		return text, offsets
 	}
-	return chineseNumbers[r]
+	return string(result), offsets
 }
 
 // normalizeNumber 将各种数字字符统一为ASCII数字
 func (p *Preprocessor) normalizeNumber(r rune) rune {
-	switch {
-	case r >= '0' && r <= '9':
-		return r
-	case r >= '０' && r <= '９': // 全角数字
-		return r - '０' + '0'
-	case r >= '⓪' && r <= '⑨': // 带圈数字
-		return r - '⓪' + '0'
-	case r >= '①' && r <= '⑨': // 带圈数字（另一种）
-		return r - '①' + '1'
-	case r >= '㈠' && r <= '㈩': // 带括号汉字数字
-		return r - '㈠' + '1'
-	case r == '零' || r == '〇':
-		return '0'
-	case r == '一':
-		return '1'
-	case r == '二':
-		return '2'
-	case r == '三':
-		return '3'
-	case r == '四':
-		return '4'
-	case r == '五':
-		return '5'
-	case r == '六':
-		return '6'
-	case r == '七':
-		return '7'
-	case r == '八':
-		return '8'
-	case r == '九':
-		return '9'
-	case r == '十':
-		return '0' // 简单处理，实际可能需要更复杂的逻辑
-	default:
-		return r
-	}
+	return normalizeNumber(r)
 }
@@ -1,6 +1,7 @@
 package preprocessor
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/ttofTnT/go-swd/pkg/core"
@@ -85,6 +86,47 @@ func TestPreprocessor_Process(t *testing.T) {
 	}
 }
 
+func TestPreprocessor_Normalizers(t *testing.T) {
+	// 删除 '.'，将 'ß' 展开为 "ss"
+	fold := core.NormalizerFunc(func(dst []rune, r rune) []rune {
+		switch r {
+		case '.':
+			return dst
+		case 'ß':
+			return append(dst, 's', 's')
+		}
+		return append(dst, r)
+	})
+	p := NewPreprocessor(core.SWDOptions{
+		IgnoreCase:  true,
+		Normalizers: []core.Normalizer{fold, fold},
+	})
+
+	got, offsets := p.ProcessWithOffsets("Gro.ß")
+	if got != "gross" {
+		t.Errorf("ProcessWithOffsets() = %v, 期望 gross", got)
+	}
+	if !reflect.DeepEqual(offsets, []int{0, 1, 2, 4, 4}) {
+		t.Errorf("偏移 = %v, 期望 [0 1 2 4 4]", offsets)
+	}
+	if got := p.Process("ok"); got != "ok" {
+		t.Errorf("Process() = %v, 期望 ok", got)
+	}
+}
+
+func TestPreprocessor_Unicode(t *testing.T) {
+	p := NewPreprocessor(core.SWDOptions{StripInvisible: true, NormalizeUnicode: true, IgnoreCase: true})
+
+	// 删除的字符不占位置，展开的字符都映射到原字符
+	got, offsets := p.ProcessWithOffsets("赌\u200b博 \U0001d405ｕ\u0301ﬀ")
+	if got != "赌博 fuff" {
+		t.Errorf("ProcessWithOffsets() = %q, 期望 \"赌博 fuff\"", got)
+	}
+	if want := []int{0, 2, 3, 4, 5, 7, 7}; !reflect.DeepEqual(offsets, want) {
+		t.Errorf("偏移 = %v, 期望 %v", offsets, want)
+	}
+}
+
 func TestPreprocessor_normalizeNumber(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,309 @@
+package preprocessor
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+)
+
+// 内置标准化阶段的名称
+const (
+	StageInvisible   = "invisible"   // 删除零宽字符、控制符等不可见字符
+	StageNFKC        = "nfkc"        // 兼容分解折叠并删除附加符号
+	StageEmoji       = "emoji"       // 删除 emoji 及其修饰符
+	StageCase        = "case"        // 转为小写
+	StageWhitespace  = "whitespace"  // 删除空白字符
+	StageWidth       = "width"       // 全角转半角
+	StageNumbers     = "numbers"     // 统一数字样式
+	StageConfusables = "confusables" // 形近字母转为拉丁字母
+	StageTraditional = "traditional" // 繁体转简体
+)
+
+// builtinOrder 内置阶段的默认顺序
+//
+// 先删除不可见字符，再做兼容折叠和删除 emoji，使其不会阻断后续阶段；大小写、空白、全半角和数字的顺序与早期版本一致。
+var builtinOrder = []string{
+	StageInvisible,
+	StageNFKC,
+	StageEmoji,
+	StageCase,
+	StageWhitespace,
+	StageWidth,
+	StageNumbers,
+	StageConfusables,
+	StageTraditional,
+}
+
+// builtins 内置阶段的实现
+var builtins = map[string]core.Normalizer{
+	StageInvisible:   dropNormalizer(isInvisible),
+	StageNFKC:        core.NormalizerFunc(foldCompat),
+	StageEmoji:       dropNormalizer(isEmoji),
+	StageCase:        mapNormalizer(unicode.ToLower),
+	StageWhitespace:  dropNormalizer(unicode.IsSpace),
+	StageWidth:       mapNormalizer(toHalfWidth),
+	StageNumbers:     mapNormalizer(normalizeNumber),
+	StageConfusables: tableNormalizer(confusables),
+	StageTraditional: tableNormalizer(traditional),
+}
+
+// BuiltinStages 按默认顺序返回所有内置阶段的名称
+func BuiltinStages() []string {
+	return append([]string(nil), builtinOrder...)
+}
+
+// NewStage 按名称创建内置阶段
+func NewStage(name string) (core.Stage, error) {
+	n, ok := builtins[name]
+	if !ok {
+		return core.Stage{}, fmt.Errorf("%w: %q", ErrStageNotFound, name)
+	}
+	return core.Stage{Name: name, Normalizer: n}, nil
+}
+
+// enabled 判断检测选项是否启用了内置阶段
+func enabled(name string, options core.SWDOptions) bool {
+	switch name {
+	case StageInvisible:
+		return options.StripInvisible
+	case StageNFKC:
+		return options.NormalizeUnicode
+	case StageEmoji:
+		return options.StripEmoji
+	case StageCase:
+		return options.IgnoreCase
+	case StageWhitespace:
+		return options.SkipWhitespace
+	case StageWidth:
+		return options.IgnoreWidth
+	case StageNumbers:
+		return options.IgnoreNumStyle
+	case StageConfusables:
+		return options.EnableSimilarShape
+	case StageTraditional:
+		return options.EnableVariantForm
+	}
+	return false
+}
+
+// mapNormalizer 将字符一对一转换的阶段
+type mapNormalizer func(r rune) rune
+
+// Normalize 实现 core.Normalizer
+func (f mapNormalizer) Normalize(dst []rune, r rune) []rune {
+	return append(dst, f(r))
+}
+
+// dropNormalizer 删除满足条件的字符的阶段
+type dropNormalizer func(r rune) bool
+
+// Normalize 实现 core.Normalizer
+func (f dropNormalizer) Normalize(dst []rune, r rune) []rune {
+	if f(r) {
+		return dst
+	}
+	return append(dst, r)
+}
+
+// tableNormalizer 按对照表一对一转换的阶段
+type tableNormalizer map[rune]rune
+
+// Normalize 实现 core.Normalizer
+func (t tableNormalizer) Normalize(dst []rune, r rune) []rune {
+	if mapped, ok := t[r]; ok {
+		return append(dst, mapped)
+	}
+	return append(dst, r)
+}
+
+// isInvisible 判断是否是不可见字符：格式控制符（零宽字符、软连字符、双向控制符、BOM、标签字符）、
+// 空白以外的控制字符、变体选择符、组合用字形连接符和韩文填充符
+func isInvisible(r rune) bool {
+	switch r {
+	case '\u034F', // 组合用字形连接符
+		'\u115F', '\u1160', '\u3164', '\uFFA0': // 韩文填充符
+		return true
+	}
+	if unicode.IsControl(r) {
+		return !unicode.IsSpace(r)
+	}
+	return unicode.Is(unicode.Cf, r) || unicode.Is(unicode.Variation_Selector, r)
+}
+
+// isDiacritic 判断是否是附加符号（组合用变音符号）
+func isDiacritic(r rune) bool {
+	switch {
+	case r >= 0x0300 && r <= 0x036F, // 组合用变音符号
+		r >= 0x1AB0 && r <= 0x1AFF, // 组合用变音符号扩展
+		r >= 0x1DC0 && r <= 0x1DFF, // 组合用变音符号补充
+		r >= 0x20D0 && r <= 0x20FF, // 符号用组合标记
+		r >= 0xFE20 && r <= 0xFE2F: // 组合用半符号
+		return true
+	}
+	return false
+}
+
+// foldCompat 按 NFKC 折叠单个字符并删除附加符号
+//
+// 数学字母数字符号、带圈字母、全角字符、上下标和连字等兼容字符分解为基本字符（如 𝐟→f、Ⓕ→F、ﬁ→fi），
+// 带附加符号的字母去掉附加符号（如 é→e），其余组合（如 が）保持合成形式。
+// 带括号的字符（如 ⑴、㈠）去掉括号。逐字符处理使每个输出字符都能映射回原文位置。
+func foldCompat(dst []rune, r rune) []rune {
+	if r < utf8.RuneSelf {
+		return append(dst, r)
+	}
+	if isDiacritic(r) {
+		return dst
+	}
+	var b [utf8.UTFMax]byte
+	d := norm.NFKD.Properties(b[:utf8.EncodeRune(b[:], r)]).Decomposition()
+	if len(d) == 0 {
+		return append(dst, r)
+	}
+	if len(d) > 2 && d[0] == '(' && d[len(d)-1] == ')' {
+		d = d[1 : len(d)-1]
+	}
+
+	start := len(dst)
+	ascii := true
+	for len(d) > 0 {
+		c, size := utf8.DecodeRune(d)
+		d = d[size:]
+		if isDiacritic(c) {
+			continue
+		}
+		if c >= utf8.RuneSelf {
+			ascii = false
+		}
+		dst = append(dst, c)
+	}
+	// 剩余多个非 ASCII 字符时重新合成，如 か+゛→が
+	if !ascii && len(dst)-start > 1 {
+		composed := norm.NFC.String(string(dst[start:]))
+		dst = append(dst[:start], []rune(composed)...)
+	}
+	return dst
+}
+
+// isEmoji 判断是否是 emoji、emoji 修饰符或 emoji 序列中的连接字符
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1F0FF: // 麻将牌、多米诺骨牌、扑克牌
+		return true
+	case r >= 0x1F1E6 && r <= 0x1FAFF: // 区域指示符（旗帜）、表情、交通和补充符号等，不含带圈字母数字
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // 杂项符号、装饰符号
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // 杂项符号和箭头
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // 标签字符（旗帜序列）
+		return true
+	case r == 0xFE0F || r == 0xFE0E: // 变体选择符
+		return true
+	case r == 0x200D || r == 0x20E3: // 零宽连接符、组合用键帽
+		return true
+	}
+	return false
+}
+
+// toHalfWidth 将全角 ASCII 字符转为半角
+func toHalfWidth(r rune) rune {
+	if r > 0xFF00 && r < 0xFF5F {
+		return r - 0xFEE0
+	}
+	return r
+}
+
+// isChineseNumber 判断是否是中文数字
+func isChineseNumber(r rune) bool {
+	switch r {
+	case '零', '〇', '一', '二', '三', '四', '五', '六', '七', '八', '九', '十':
+		return true
+	}
+	return false
+}
+
+// normalizeNumber 将各种数字字符统一为ASCII数字
+func normalizeNumber(r rune) rune {
+	if !unicode.IsNumber(r) && !isChineseNumber(r) {
+		return r
+	}
+	switch {
+	case r >= '0' && r <= '9':
+		return r
+	case r >= '０' && r <= '９': // 全角数字
+		return r - '０' + '0'
+	case r >= '⓪' && r <= '⑨': // 带圈数字
+		return r - '⓪' + '0'
+	case r >= '①' && r <= '⑨': // 带圈数字（另一种）
+		return r - '①' + '1'
+	case r >= '㈠' && r <= '㈩': // 带括号汉字数字
+		return r - '㈠' + '1'
+	case r == '零' || r == '〇':
+		return '0'
+	case r == '一':
+		return '1'
+	case r == '二':
+		return '2'
+	case r == '三':
+		return '3'
+	case r == '四':
+		return '4'
+	case r == '五':
+		return '5'
+	case r == '六':
+		return '6'
+	case r == '七':
+		return '7'
+	case r == '八':
+		return '8'
+	case r == '九':
+		return '9'
+	case r == '十':
+		return '0' // 简单处理，实际可能需要更复杂的逻辑
+	default:
+		return r
+	}
+}
+
+// confusables 与拉丁字母形近的西里尔、希腊字母
+var confusables = map[rune]rune{
+	// 西里尔字母
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+	'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'ѕ': 's', 'і': 'i', 'ј': 'j',
+	'ԁ': 'd', 'һ': 'h', 'ԛ': 'q', 'ԝ': 'w',
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O', 'Р': 'P',
+	'С': 'C', 'Т': 'T', 'У': 'Y', 'Х': 'X', 'Ѕ': 'S', 'І': 'I', 'Ј': 'J',
+	// 希腊字母
+	'α': 'a', 'β': 'b', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p',
+	'τ': 't', 'υ': 'u', 'χ': 'x',
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M',
+	'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+}
+
+// traditional 常用繁体字到简体字的对照表，侧重敏感词中常见的字
+var traditional = map[rune]rune{
+	'賭': '赌', '場': '场', '錢': '钱', '莊': '庄', '幣': '币', '貸': '贷', '購': '购', '買': '买',
+	'賣': '卖', '銷': '销', '傳': '传', '發': '发', '產': '产', '費': '费', '號': '号', '碼': '码',
+	'證': '证', '務': '务', '網': '网', '絡': '络', '機': '机', '點': '点', '體': '体', '門': '门',
+	'開': '开', '關': '关', '國': '国', '黨': '党', '獨': '独', '軍': '军', '隊': '队', '戰': '战',
+	'爭': '争', '權': '权', '領': '领', '導': '导', '選': '选', '舉': '举', '議': '议', '員': '员',
+	'興': '兴', '鬥': '斗', '輪': '轮', '衛': '卫', '運': '运', '動': '动', '亂': '乱', '藥': '药',
+	'販': '贩', '緝': '缉', '詐': '诈', '騙': '骗', '盜': '盗', '竊': '窃', '罰': '罚', '獄': '狱',
+	'殺': '杀', '槍': '枪', '彈': '弹', '擊': '击', '襲': '袭', '屍': '尸', '黃': '黄', '穢': '秽',
+	'賤': '贱', '媽': '妈', '雜': '杂', '種': '种', '婦': '妇', '姦': '奸', '愛': '爱', '戀': '恋',
+	'約': '约', '夥': '伙', '們': '们', '這': '这', '個': '个', '來': '来', '說': '说', '時': '时',
+	'會': '会', '為': '为', '對': '对', '麼': '么', '經': '经', '與': '与', '東': '东', '車': '车',
+	'長': '长', '馬': '马', '鳥': '鸟', '魚': '鱼', '雞': '鸡', '龍': '龙', '讓': '让', '認': '认',
+	'識': '识', '話': '话', '語': '语', '請': '请', '謝': '谢', '還': '还', '進': '进', '過': '过',
+	'親': '亲', '樂': '乐', '頭': '头', '氣': '气', '處': '处', '應': '应', '當': '当', '從': '从',
+	'後': '后', '裡': '里', '裏': '里', '學': '学', '習': '习', '聽': '听', '見': '见', '覺': '觉',
+	'計': '计', '劃': '划', '無': '无', '幾': '几', '現': '现', '實': '实', '蕩': '荡', '嬌': '娇',
+	'豔': '艳', '艷': '艳', '獸': '兽', '膽': '胆', '腦': '脑', '癡': '痴', '瘋': '疯', '惡': '恶',
+	'滅': '灭', '絕': '绝', '殘': '残', '辦': '办', '偽': '伪', '僞': '伪', '鈔': '钞', '銀': '银',
+	'帳': '账', '賬': '账', '戶': '户', '貨': '货', '幫': '帮', '團': '团', '衝': '冲', '鋒': '锋',
+}
@@ -0,0 +1,57 @@
+package preprocessor
+
+import "github.com/ttofTnT/go-swd/pkg/core"
+
+// leetspeak 常见的 leetspeak 和符号替换，候选字符均为小写，需配合 IgnoreCase 使用
+var leetspeak = core.Substitutions{
+	'0': {'o'},
+	'1': {'i', 'l'},
+	'3': {'e'},
+	'4': {'a'},
+	'5': {'s'},
+	'7': {'t'},
+	'8': {'b'},
+	'9': {'g'},
+	'@': {'a'},
+	'$': {'s'},
+	'!': {'i'},
+	'|': {'l', 'i'},
+	'+': {'t'},
+	'(': {'c'},
+	'€': {'e'},
+}
+
+// Leetspeak 返回内置 leetspeak 替换表的副本
+func Leetspeak() core.Substitutions {
+	return merge(nil, leetspeak)
+}
+
+// SubstitutionTable 返回检测选项启用的替换字符表，未启用时返回 nil
+//
+// EnableLeetspeak 启用内置表，options.Substitutions 中的字符覆盖内置表中的同名字符。
+// 替换在所有预处理阶段之后生效，键应是预处理后的字符，如启用 IgnoreWidth 时使用半角字符。
+func SubstitutionTable(options core.SWDOptions) core.Substitutions {
+	var table core.Substitutions
+	if options.EnableLeetspeak {
+		table = merge(table, leetspeak)
+	}
+	table = merge(table, options.Substitutions)
+	if len(table) == 0 {
+		return nil
+	}
+	return table
+}
+
+// merge 将 src 中的字符复制到 dst，dst 为 nil 时新建
+func merge(dst, src core.Substitutions) core.Substitutions {
+	if len(src) == 0 {
+		return dst
+	}
+	if dst == nil {
+		dst = make(core.Substitutions, len(src))
+	}
+	for r, candidates := range src {
+		dst[r] = append([]rune(nil), candidates...)
+	}
+	return dst
+}
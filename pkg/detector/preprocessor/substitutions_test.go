@@ -0,0 +1,49 @@
+package preprocessor
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+)
+
+func TestSubstitutionTable(t *testing.T) {
+	if got := SubstitutionTable(core.SWDOptions{}); got != nil {
+		t.Errorf("未启用时 SubstitutionTable() = %v, 期望 nil", got)
+	}
+
+	custom := core.Substitutions{'1': {'i'}, 'v': {'u'}}
+	table := SubstitutionTable(core.SWDOptions{EnableLeetspeak: true, Substitutions: custom})
+	if !reflect.DeepEqual(table['1'], []rune{'i'}) || !reflect.DeepEqual(table['v'], []rune{'u'}) {
+		t.Errorf("自定义表应覆盖内置表: 1=%q v=%q", table['1'], table['v'])
+	}
+	if !reflect.DeepEqual(table['4'], []rune{'a'}) {
+		t.Errorf("内置表缺少 4: %q", table['4'])
+	}
+
+	// 返回的是副本，修改不影响内置表
+	table['4'][0] = 'x'
+	if Leetspeak()['4'][0] != 'a' {
+		t.Error("修改 SubstitutionTable() 的结果不应影响内置表")
+	}
+}
+
+func TestSubstitutions_JSON(t *testing.T) {
+	var options core.SWDOptions
+	if err := json.Unmarshal([]byte(`{"enable_leetspeak":true,"substitutions":{"1":"il","$":"s"}}`), &options); err != nil {
+		t.Fatalf("json.Unmarshal() 错误 = %v", err)
+	}
+	want := core.Substitutions{'1': {'i', 'l'}, '$': {'s'}}
+	if !options.EnableLeetspeak || !reflect.DeepEqual(options.Substitutions, want) {
+		t.Errorf("Substitutions = %v, 期望 %v", options.Substitutions, want)
+	}
+
+	data, err := json.Marshal(want)
+	if err != nil || string(data) != `{"$":"s","1":"il"}` {
+		t.Errorf("json.Marshal() = %s, %v", data, err)
+	}
+	if err := json.Unmarshal([]byte(`{"ab":"c"}`), &options.Substitutions); err == nil {
+		t.Error("多个字符的键应返回错误")
+	}
+}
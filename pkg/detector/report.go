@@ -0,0 +1,100 @@
+package detector
+
+import (
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/detector/preprocessor"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// Report 检测文本并生成检测报告，匹配位置映射回原文
+func (d *detector) Report(text string) core.DetectionReport {
+	started := time.Now()
+	processedText, offsets := d.preprocess.ProcessWithOffsets(text)
+
+	d.mu.RLock()
+	var matches []core.SensitiveWord
+	if text != "" {
+		matches = d.collectProcessed(processedText)
+	}
+	version := d.version
+	options := d.options
+	d.mu.RUnlock()
+
+	report := BuildReport(text, matches, offsets, options)
+	report.DictVersion = version
+	report.StartedAt = started
+	report.DurationNanos = time.Since(started).Nanoseconds()
+	d.observeScan("Report", started, matches)
+	return report
+}
+
+// BuildReport 根据匹配结果生成检测报告，不含时间信息
+//
+// offsets 为预处理后每个字符在原文中的字符偏移，为 nil 时认为匹配位置即原文位置。
+func BuildReport(text string, matches []core.SensitiveWord, offsets []int, options core.SWDOptions) core.DetectionReport {
+	runes := []rune(text)
+	byteOffsets := make([]int, len(runes)+1)
+	for i, pos := 0, 0; i < len(runes); i++ {
+		byteOffsets[i] = pos
+		pos += utf8.RuneLen(runes[i])
+		byteOffsets[i+1] = pos
+	}
+
+	report := core.DetectionReport{
+		SchemaVersion: core.ReportSchemaVersion,
+		InputHash:     core.HashInput(text),
+		InputLength:   len(runes),
+		Detected:      len(matches) > 0,
+		Matches:       make([]core.ReportMatch, 0, len(matches)),
+		Categories:    []category.Category{},
+		Score:         ScoreMatches(matches, options).Total,
+		Options:       options,
+	}
+
+	cats := category.NewSet()
+	for _, match := range matches {
+		start, end := preprocessor.OriginalSpan(offsets, match.StartPos, match.EndPos)
+		if start < 0 || end > len(runes) || start >= end {
+			continue
+		}
+		rm := core.ReportMatch{
+			Word:       match.Word,
+			Text:       string(runes[start:end]),
+			Start:      start,
+			End:        end,
+			ByteStart:  byteOffsets[start],
+			ByteEnd:    byteOffsets[end],
+			Category:   match.Category,
+			Categories: match.Categories,
+		}
+		if match.Meta != nil {
+			rm.Severity = match.Meta.Severity
+			rm.Action = match.Meta.Action
+			rm.Tags = match.Meta.Tags
+		}
+		if rm.Severity > report.Severity {
+			report.Severity = rm.Severity
+		}
+		for _, cat := range match.AllCategories() {
+			cats.Add(cat)
+		}
+		if match.DictVersion > report.DictVersion {
+			report.DictVersion = match.DictVersion
+		}
+		report.Matches = append(report.Matches, rm)
+	}
+	sort.SliceStable(report.Matches, func(i, j int) bool {
+		if report.Matches[i].Start != report.Matches[j].Start {
+			return report.Matches[i].Start < report.Matches[j].Start
+		}
+		return report.Matches[i].End < report.Matches[j].End
+	})
+	if found := cats.Categories(); len(found) > 0 {
+		report.Categories = found
+	}
+	return report
+}
@@ -0,0 +1,137 @@
+package detector
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+func TestDetector_Report(t *testing.T) {
+	d, err := NewDetector(core.SWDOptions{SkipWhitespace: true})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+	observer := d.(*detector)
+	observer.OnWordsChanged(map[string]category.Category{
+		"赌场": category.Gambling,
+		"骗子": category.Scam,
+	})
+	observer.OnMetaChanged(map[string]core.WordMeta{
+		"赌场": {Severity: 4, Action: "block", Tags: []string{"线上"}},
+	})
+
+	text := "a 赌 场里有骗子"
+	report := d.(core.Reporter).Report(text)
+
+	if report.SchemaVersion != core.ReportSchemaVersion || report.InputHash != core.HashInput(text) {
+		t.Errorf("报告头部错误: %+v", report)
+	}
+	if !strings.HasPrefix(report.InputHash, "sha256:") || report.InputLength != 9 {
+		t.Errorf("InputHash = %q, InputLength = %d", report.InputHash, report.InputLength)
+	}
+	if !report.Detected || report.Severity != 4 || report.Score != 4 {
+		t.Errorf("Detected = %v, Severity = %d, Score = %v", report.Detected, report.Severity, report.Score)
+	}
+	if len(report.Categories) != 2 || report.Categories[0] != category.Gambling || report.Categories[1] != category.Scam {
+		t.Errorf("Categories = %v, 期望 [赌博 诈骗]", report.Categories)
+	}
+	if !report.Options.SkipWhitespace || report.StartedAt.IsZero() || report.DurationNanos < 0 {
+		t.Errorf("Options/时间信息错误: %+v", report)
+	}
+
+	// 匹配位置映射回原文，跳过的空白包含在片段内
+	if len(report.Matches) != 2 {
+		t.Fatalf("Matches = %+v, 期望 2 个", report.Matches)
+	}
+	gambling := report.Matches[0]
+	if gambling.Word != "赌场" || gambling.Text != "赌 场" || gambling.Start != 2 || gambling.End != 5 {
+		t.Errorf("Matches[0] = %+v, 期望原文片段 \"赌 场\" [2,5)", gambling)
+	}
+	if text[gambling.ByteStart:gambling.ByteEnd] != gambling.Text {
+		t.Errorf("字节偏移 [%d,%d) 与片段不一致", gambling.ByteStart, gambling.ByteEnd)
+	}
+	if gambling.Action != "block" || len(gambling.Tags) != 1 {
+		t.Errorf("Matches[0] 元数据错误: %+v", gambling)
+	}
+	if scam := report.Matches[1]; scam.Text != "骗子" || scam.Start != 7 || scam.End != 9 || scam.Severity != 0 {
+		t.Errorf("Matches[1] = %+v, 期望原文片段 \"骗子\" [7,9)", scam)
+	}
+
+	// JSON 字段稳定，分类输出为 ASCII 标识
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("json.Marshal() 错误 = %v", err)
+	}
+	for _, field := range []string{`"schema_version":"1"`, `"categories":["gambling","scam"]`, `"byte_start":`, `"duration_ns":`, `"skip_whitespace":true`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("JSON 中缺少 %s: %s", field, data)
+		}
+	}
+}
+
+func TestDetector_ReportUnicode(t *testing.T) {
+	d, err := NewDetector(core.SWDOptions{IgnoreCase: true, StripInvisible: true, NormalizeUnicode: true})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+	d.(*detector).OnWordsChanged(map[string]category.Category{
+		"fuck": category.Profanity,
+		"赌博":   category.Gambling,
+	})
+
+	// 数学字母和零宽字符折叠后命中，位置映射回原文
+	text := "说\U0001d41f\U0001d42e\U0001d41c\U0001d424和赌\u200b博"
+	report := d.(core.Reporter).Report(text)
+	if len(report.Matches) != 2 {
+		t.Fatalf("Matches = %+v, 期望 2 个", report.Matches)
+	}
+	for i, want := range []struct {
+		text       string
+		start, end int
+	}{
+		{"\U0001d41f\U0001d42e\U0001d41c\U0001d424", 1, 5},
+		{"赌\u200b博", 6, 9},
+	} {
+		m := report.Matches[i]
+		if m.Text != want.text || m.Start != want.start || m.End != want.end || text[m.ByteStart:m.ByteEnd] != want.text {
+			t.Errorf("Matches[%d] = %+v, 期望原文片段 %q [%d,%d)", i, m, want.text, want.start, want.end)
+		}
+	}
+}
+
+func TestDetector_ReportLeetspeak(t *testing.T) {
+	d, err := NewDetector(core.SWDOptions{IgnoreCase: true, IgnoreWidth: true, EnableLeetspeak: true})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+	d.(*detector).OnWordsChanged(map[string]category.Category{"shit": category.Profanity})
+
+	// 全角 ＄ 先转为半角再按替换表匹配，原文片段保持不变
+	text := "oh ＄H1t"
+	if !d.Detect(text) {
+		t.Errorf("Detect(%q) = false, 期望 true", text)
+	}
+	report := d.(core.Reporter).Report(text)
+	if len(report.Matches) != 1 || report.Matches[0].Word != "shit" || report.Matches[0].Text != "＄H1t" {
+		t.Errorf("Matches = %+v, 期望原文片段 \"＄H1t\"", report.Matches)
+	}
+}
+
+func TestDetector_ReportEmpty(t *testing.T) {
+	d, err := NewDetector(core.SWDOptions{})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+	report := d.(core.Reporter).Report("")
+	if report.Detected || report.Matches == nil || report.Categories == nil {
+		t.Errorf("空文本报告 = %+v, 期望未命中且列表非 nil", report)
+	}
+
+	data, _ := json.Marshal(report)
+	if !strings.Contains(string(data), `"matches":[]`) {
+		t.Errorf("无匹配时 matches 应序列化为空列表: %s", data)
+	}
+}
@@ -0,0 +1,88 @@
+package detector
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// defaultScoreDecay decayed-sum 的默认衰减系数
+const defaultScoreDecay = 0.5
+
+// ErrUnknownAggregation 未知的风险评分聚合方式
+var ErrUnknownAggregation = errors.New("unknown score aggregation")
+
+// validateAggregation 校验评分聚合方式，空值表示默认的 max
+func validateAggregation(aggregation core.ScoreAggregation) error {
+	switch aggregation {
+	case "", core.ScoreMax, core.ScoreSum, core.ScoreDecayedSum:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownAggregation, aggregation)
+	}
+}
+
+// ScoreMatches 按配置的聚合方式计算匹配结果的风险评分
+func ScoreMatches(matches []core.SensitiveWord, options core.SWDOptions) core.RiskScore {
+	score := core.RiskScore{
+		Categories: make(map[category.Category]float64),
+		Matches:    matches,
+	}
+	if len(matches) == 0 {
+		return score
+	}
+
+	all := make([]float64, 0, len(matches))
+	byCategory := make(map[category.Category][]float64)
+	for _, match := range matches {
+		weight := match.Weight()
+		all = append(all, weight)
+		for _, cat := range match.AllCategories() {
+			byCategory[cat] = append(byCategory[cat], weight)
+		}
+	}
+
+	score.Total = aggregate(all, options)
+	for cat, weights := range byCategory {
+		score.Categories[cat] = aggregate(weights, options)
+	}
+	return score
+}
+
+// aggregate 聚合一组权重
+func aggregate(weights []float64, options core.SWDOptions) float64 {
+	switch options.ScoreAggregation {
+	case core.ScoreSum:
+		var total float64
+		for _, w := range weights {
+			total += w
+		}
+		return total
+	case core.ScoreDecayedSum:
+		decay := options.ScoreDecay
+		if decay <= 0 || decay > 1 {
+			decay = defaultScoreDecay
+		}
+		sorted := append([]float64(nil), weights...)
+		sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+
+		var total float64
+		factor := 1.0
+		for _, w := range sorted {
+			total += w * factor
+			factor *= decay
+		}
+		return total
+	default:
+		var highest float64
+		for _, w := range weights {
+			if w > highest {
+				highest = w
+			}
+		}
+		return highest
+	}
+}
@@ -0,0 +1,138 @@
+package detector
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+func TestScoreMatches(t *testing.T) {
+	matches := []core.SensitiveWord{
+		{Word: "赌场", Category: category.Gambling, Meta: &core.WordMeta{Severity: 3}},
+		{Word: "骗子", Category: category.Scam, Meta: &core.WordMeta{Severity: 2}},
+		{Word: "下注", Category: category.Gambling},
+	}
+
+	tests := []struct {
+		name       string
+		options    core.SWDOptions
+		total      float64
+		categories map[category.Category]float64
+	}{
+		{
+			name:       "默认取最大值",
+			options:    core.SWDOptions{},
+			total:      3,
+			categories: map[category.Category]float64{category.Gambling: 3, category.Scam: 2},
+		},
+		{
+			name:       "求和",
+			options:    core.SWDOptions{ScoreAggregation: core.ScoreSum},
+			total:      6,
+			categories: map[category.Category]float64{category.Gambling: 4, category.Scam: 2},
+		},
+		{
+			name:       "衰减求和-默认系数",
+			options:    core.SWDOptions{ScoreAggregation: core.ScoreDecayedSum},
+			total:      3 + 2*0.5 + 1*0.25,
+			categories: map[category.Category]float64{category.Gambling: 3.5, category.Scam: 2},
+		},
+		{
+			name:       "衰减求和-自定义系数",
+			options:    core.SWDOptions{ScoreAggregation: core.ScoreDecayedSum, ScoreDecay: 0.1},
+			total:      3 + 2*0.1 + 1*0.01,
+			categories: map[category.Category]float64{category.Gambling: 3.1, category.Scam: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ScoreMatches(matches, tt.options)
+			if math.Abs(got.Total-tt.total) > 1e-9 {
+				t.Errorf("Total = %v, 期望 %v", got.Total, tt.total)
+			}
+			if len(got.Categories) != len(tt.categories) {
+				t.Fatalf("Categories = %v, 期望 %v", got.Categories, tt.categories)
+			}
+			for cat, want := range tt.categories {
+				if math.Abs(got.Categories[cat]-want) > 1e-9 {
+					t.Errorf("Categories[%s] = %v, 期望 %v", cat, got.Categories[cat], want)
+				}
+			}
+		})
+	}
+}
+
+func TestScoreMatches_Empty(t *testing.T) {
+	got := ScoreMatches(nil, core.SWDOptions{ScoreAggregation: core.ScoreSum})
+	if got.Total != 0 || len(got.Categories) != 0 {
+		t.Errorf("ScoreMatches(nil) = %+v, 期望零值", got)
+	}
+}
+
+func TestDetector_Score(t *testing.T) {
+	d, err := NewDetector(core.SWDOptions{ScoreAggregation: core.ScoreSum})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+
+	observer := d.(*detector)
+	observer.OnWordsChanged(map[string]category.Category{
+		"赌场": category.Gambling,
+		"骗子": category.Scam,
+	})
+	observer.OnMetaChanged(map[string]core.WordMeta{
+		"赌场": {Severity: 5},
+	})
+
+	got := d.Score("赌场里都是骗子")
+	if got.Total != 6 {
+		t.Errorf("Score().Total = %v, 期望 6", got.Total)
+	}
+	if got.Categories[category.Gambling] != 5 || got.Categories[category.Scam] != 1 {
+		t.Errorf("Score().Categories = %v", got.Categories)
+	}
+}
+
+func TestNewDetector_UnknownAggregation(t *testing.T) {
+	words := map[string]category.Category{"赌场": category.Gambling}
+	for _, aggregation := range []core.ScoreAggregation{"", core.ScoreMax, core.ScoreSum, core.ScoreDecayedSum} {
+		if _, err := NewDetectorWithWords(core.SWDOptions{ScoreAggregation: aggregation}, words, 0); err != nil {
+			t.Errorf("聚合方式 %q 应有效: %v", aggregation, err)
+		}
+	}
+
+	_, err := NewDetectorWithWords(core.SWDOptions{ScoreAggregation: "avg"}, words, 0)
+	if !errors.Is(err, ErrUnknownAggregation) {
+		t.Errorf("未知聚合方式应返回 ErrUnknownAggregation，实际为 %v", err)
+	}
+}
+
+func TestDetector_ScoreConcurrentSetLogger(t *testing.T) {
+	words := map[string]category.Category{"赌场": category.Gambling}
+	d, err := NewDetectorWithWords(core.SWDOptions{}, words, 0)
+	if err != nil {
+		t.Fatalf("NewDetectorWithWords() 错误 = %v", err)
+	}
+	setter := d.(core.LoggerSetter)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			setter.SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		d.Score("去赌场")
+		d.(core.Reporter).Report("去赌场")
+	}
+	wg.Wait()
+}
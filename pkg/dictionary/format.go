@@ -0,0 +1,460 @@
+package dictionary
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// entry 词库中的一条记录
+type entry struct {
+	word        string
+	cat         category.Category
+	meta        core.WordMeta
+	replaceMeta bool // 元数据为空时是否清除已有元数据
+}
+
+// record JSON/CSV 格式的词条结构
+type record struct {
+	Word        string   `json:"word"`
+	Category    string   `json:"category,omitempty"`
+	Severity    int      `json:"severity,omitempty"`
+	Action      string   `json:"action,omitempty"`
+	Replacement string   `json:"replacement,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Note        string   `json:"note,omitempty"`
+}
+
+// csvHeader CSV 格式的列名
+var csvHeader = []string{"word", "category", "severity", "action", "replacement", "tags", "note"}
+
+// formatFromPath 根据文件扩展名判断词库格式
+func formatFromPath(path string) core.DictFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return core.DictFormatJSON
+	case ".csv":
+		return core.DictFormatCSV
+	default:
+		return core.DictFormatText
+	}
+}
+
+// categoryFromPath 根据文件名推断默认分类，如 gambling.txt => 赌博
+//
+// 子分类文件位于父分类同名目录下，如 violence/firearms.txt，优先按“目录/文件名”查找。
+func categoryFromPath(reg *category.Registry, path string) category.Category {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if parent := filepath.Base(filepath.Dir(path)); parent != "." && parent != string(filepath.Separator) {
+		if cat, ok := reg.Parse(parent + category.PathSeparator + name); ok {
+			return cat
+		}
+	}
+	if cat, ok := reg.Parse(name); ok {
+		return cat
+	}
+	return category.None
+}
+
+// categoryFile 返回分类在按分类导出的目录中对应的文件，与 default 目录的布局一致
+func categoryFile(reg *category.Registry, cat category.Category) string {
+	if cat == category.None {
+		return "all.txt"
+	}
+	return filepath.FromSlash(reg.Format(cat)) + ".txt"
+}
+
+// encodeWordList 每行输出一个不带标注的词条，词条无法按原样读回时返回错误
+func encodeWordList(w io.Writer, reg *category.Registry, cat category.Category, words []string) error {
+	bw := bufio.NewWriter(w)
+	for _, word := range words {
+		e, err := parseLine(word, reg, cat)
+		if err != nil || e.word != word || e.cat != cat || strings.TrimSpace(word) != word ||
+			strings.HasPrefix(word, "#") || strings.ContainsAny(word, "\r\n") {
+			return fmt.Errorf("word %q cannot be represented in text format", word)
+		}
+		bw.WriteString(word)
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+// decodeEntries 按格式解析词库内容，分类名称在 reg 中查找，解析完成前不会修改词库
+func decodeEntries(ctx context.Context, r io.Reader, format core.DictFormat, reg *category.Registry, defaultCat category.Category) ([]entry, error) {
+	switch format {
+	case core.DictFormatText, "":
+		return decodeText(ctx, r, reg, defaultCat)
+	case core.DictFormatJSON:
+		return decodeJSON(r, reg, defaultCat)
+	case core.DictFormatCSV:
+		return decodeCSV(r, reg, defaultCat)
+	default:
+		return nil, fmt.Errorf("unsupported dictionary format: %q", format)
+	}
+}
+
+// decodeText 解析文本格式，每行一个词条：
+//
+//	词条
+//	词条|分类|severity=3|action=block|replacement=**|tags=a,b|note=备注
+//
+// 分类字段可省略，省略时使用文件默认分类；以 # 开头的行为注释
+func decodeText(ctx context.Context, r io.Reader, reg *category.Registry, defaultCat category.Category) ([]entry, error) {
+	scanner := bufio.NewScanner(r)
+	const batchSize = 1000
+	var entries []entry
+	lineNo := 0
+
+	for scanner.Scan() {
+		if lineNo%batchSize == 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+		}
+		lineNo++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		e, err := parseLine(line, reg, defaultCat)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// parseLine 解析一行文本格式的词条
+func parseLine(line string, reg *category.Registry, defaultCat category.Category) (entry, error) {
+	fields := strings.Split(line, "|")
+	if !hasAnnotation(fields, reg) {
+		// 既无分类也无元数据的行按原样视为词条，兼容含 | 的旧词库
+		return entry{word: line, cat: defaultCat}, nil
+	}
+	e := entry{word: strings.TrimSpace(fields[0]), cat: defaultCat}
+	if e.word == "" {
+		return e, errors.New("word cannot be empty")
+	}
+
+	for i, field := range fields[1:] {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			// 仅第二个字段允许为分类名
+			if i != 0 {
+				return e, fmt.Errorf("invalid field %q", field)
+			}
+			cat, ok := reg.Parse(field)
+			if !ok {
+				return e, fmt.Errorf("unknown category %q", field)
+			}
+			e.cat = cat
+			continue
+		}
+		if err := setMetaField(&e.meta, strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return e, err
+		}
+	}
+	return e, nil
+}
+
+// hasAnnotation 判断一行是否带有分类或元数据标注
+func hasAnnotation(fields []string, reg *category.Registry) bool {
+	if len(fields) < 2 {
+		return false
+	}
+	for _, field := range fields[1:] {
+		if strings.Contains(field, "=") {
+			return true
+		}
+	}
+	_, ok := reg.Parse(strings.TrimSpace(fields[1]))
+	return ok
+}
+
+// setMetaField 设置元数据字段
+func setMetaField(meta *core.WordMeta, key, value string) error {
+	switch strings.ToLower(key) {
+	case "severity":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid severity %q", value)
+		}
+		meta.Severity = n
+	case "action":
+		meta.Action = value
+	case "replacement":
+		meta.Replacement = value
+	case "tags":
+		meta.Tags = splitTags(value)
+	case "note":
+		meta.Note = value
+	default:
+		return fmt.Errorf("unknown field %q", key)
+	}
+	return nil
+}
+
+// splitTags 解析逗号分隔的标签
+func splitTags(value string) []string {
+	var tags []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// decodeJSON 解析 JSON 词条数组
+func decodeJSON(r io.Reader, reg *category.Registry, defaultCat category.Category) ([]entry, error) {
+	var records []record
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, fmt.Errorf("invalid json: %w", err)
+	}
+
+	entries := make([]entry, 0, len(records))
+	for i, rec := range records {
+		e, err := rec.toEntry(reg, defaultCat)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// decodeCSV 解析带表头的 CSV，列顺序不限，除 word 外均可省略
+func decodeCSV(r io.Reader, reg *category.Registry, defaultCat category.Category) ([]entry, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["word"]; !ok {
+		return nil, errors.New("csv header must contain a word column")
+	}
+
+	var entries []entry
+	for row := 2; ; row++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		rec := record{
+			Word:        get("word"),
+			Category:    get("category"),
+			Action:      get("action"),
+			Replacement: get("replacement"),
+			Tags:        splitTags(get("tags")),
+			Note:        get("note"),
+		}
+		if s := get("severity"); s != "" {
+			if rec.Severity, err = strconv.Atoi(s); err != nil {
+				return nil, fmt.Errorf("row %d: invalid severity %q", row, s)
+			}
+		}
+		e, err := rec.toEntry(reg, defaultCat)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// toEntry 将记录转换为词条
+func (rec record) toEntry(reg *category.Registry, defaultCat category.Category) (entry, error) {
+	e := entry{
+		word: strings.TrimSpace(rec.Word),
+		cat:  defaultCat,
+		meta: core.WordMeta{
+			Severity:    rec.Severity,
+			Action:      rec.Action,
+			Replacement: rec.Replacement,
+			Tags:        rec.Tags,
+			Note:        rec.Note,
+		},
+	}
+	if e.word == "" {
+		return e, errors.New("word cannot be empty")
+	}
+	if rec.Severity < 0 {
+		return e, fmt.Errorf("invalid severity %d", rec.Severity)
+	}
+	if rec.Category != "" {
+		cat, ok := reg.Parse(rec.Category)
+		if !ok {
+			return e, fmt.Errorf("unknown category %q", rec.Category)
+		}
+		e.cat = cat
+	}
+	return e, nil
+}
+
+// sortEntries 按分类、词条排序，保证导出结果稳定
+func sortEntries(entries []entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].cat != entries[j].cat {
+			return entries[i].cat < entries[j].cat
+		}
+		return entries[i].word < entries[j].word
+	})
+}
+
+// encodeEntries 按格式输出词条，分类名称在 reg 中查找，调用方需先排序
+func encodeEntries(w io.Writer, format core.DictFormat, reg *category.Registry, entries []entry) error {
+	switch format {
+	case core.DictFormatText, "":
+		return encodeText(w, reg, entries)
+	case core.DictFormatJSON:
+		return encodeJSON(w, reg, entries)
+	case core.DictFormatCSV:
+		return encodeCSV(w, reg, entries)
+	default:
+		return fmt.Errorf("unsupported dictionary format: %q", format)
+	}
+}
+
+// encodeText 输出文本格式，按分类分组，每组以注释行开头
+func encodeText(w io.Writer, reg *category.Registry, entries []entry) error {
+	bw := bufio.NewWriter(w)
+	for i, e := range entries {
+		if i == 0 || e.cat != entries[i-1].cat {
+			if i > 0 {
+				bw.WriteString("\n")
+			}
+			fmt.Fprintf(bw, "# %s\n", categoryName(reg, e.cat))
+		}
+		line, err := formatLine(reg, e)
+		if err != nil {
+			return err
+		}
+		bw.WriteString(line)
+		bw.WriteString("\n")
+	}
+	return bw.Flush()
+}
+
+// formatLine 将词条格式化为一行文本，无法被 parseLine 还原时返回错误
+func formatLine(reg *category.Registry, e entry) (string, error) {
+	if strings.HasPrefix(e.word, "#") || strings.TrimSpace(e.word) != e.word {
+		return "", fmt.Errorf("word %q cannot be represented in text format", e.word)
+	}
+
+	fields := []string{e.word, categoryName(reg, e.cat)}
+	if e.meta.Severity != 0 {
+		fields = append(fields, "severity="+strconv.Itoa(e.meta.Severity))
+	}
+	for _, kv := range [][2]string{
+		{"action", e.meta.Action},
+		{"replacement", e.meta.Replacement},
+		{"tags", strings.Join(e.meta.Tags, ",")},
+		{"note", e.meta.Note},
+	} {
+		if kv[1] != "" {
+			fields = append(fields, kv[0]+"="+kv[1])
+		}
+	}
+	for _, field := range fields {
+		if strings.ContainsAny(field, "|\r\n") {
+			return "", fmt.Errorf("word %q cannot be represented in text format", e.word)
+		}
+	}
+	return strings.Join(fields, "|"), nil
+}
+
+// encodeJSON 输出 JSON 词条数组
+func encodeJSON(w io.Writer, reg *category.Registry, entries []entry) error {
+	records := make([]record, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, e.toRecord(reg))
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+// encodeCSV 输出带表头的 CSV
+func encodeCSV(w io.Writer, reg *category.Registry, entries []entry) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		rec := e.toRecord(reg)
+		severity := ""
+		if rec.Severity != 0 {
+			severity = strconv.Itoa(rec.Severity)
+		}
+		row := []string{rec.Word, rec.Category, severity, rec.Action, rec.Replacement, strings.Join(rec.Tags, ","), rec.Note}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// categoryName 返回分类在注册表中的名称，未注册时返回 Category.String()
+func categoryName(reg *category.Registry, cat category.Category) string {
+	if name, ok := reg.Name(cat); ok {
+		return name
+	}
+	return cat.String()
+}
+
+// toRecord 将词条转换为记录
+func (e entry) toRecord(reg *category.Registry) record {
+	return record{
+		Word:        e.word,
+		Category:    categoryName(reg, e.cat),
+		Severity:    e.meta.Severity,
+		Action:      e.meta.Action,
+		Replacement: e.meta.Replacement,
+		Tags:        e.meta.Tags,
+		Note:        e.meta.Note,
+	}
+}
@@ -0,0 +1,310 @@
+package dictionary
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// TestParseLine 测试解析文本格式的词条
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    entry
+		wantErr bool
+	}{
+		{
+			name: "纯词条",
+			line: "赌场",
+			want: entry{word: "赌场", cat: category.Political},
+		},
+		{
+			name: "完整元数据",
+			line: "赌场|gambling|severity=3|action=block|replacement=**|tags=线上, 高危|note=示例",
+			want: entry{
+				word: "赌场",
+				cat:  category.Gambling,
+				meta: core.WordMeta{
+					Severity:    3,
+					Action:      "block",
+					Replacement: "**",
+					Tags:        []string{"线上", "高危"},
+					Note:        "示例",
+				},
+			},
+		},
+		{
+			name: "中文分类名",
+			line: "赌场|赌博",
+			want: entry{word: "赌场", cat: category.Gambling},
+		},
+		{
+			name: "省略分类",
+			line: "赌场|severity=1",
+			want: entry{word: "赌场", cat: category.Political, meta: core.WordMeta{Severity: 1}},
+		},
+		{
+			name: "无标注的竖线按原词保留",
+			line: "猎枪|真枪",
+			want: entry{word: "猎枪|真枪", cat: category.Political},
+		},
+		{name: "未知分类", line: "赌场|unknown|severity=1", wantErr: true},
+		{name: "未知字段", line: "赌场|gambling|level=3", wantErr: true},
+		{name: "非法严重程度", line: "赌场|gambling|severity=high", wantErr: true},
+		{name: "分类位置错误", line: "赌场|severity=1|gambling", wantErr: true},
+		{name: "空词条", line: "|gambling", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLine(tt.line, category.Default, category.Political)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestLoadFrom 测试按格式加载词库
+func TestLoadFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  core.DictFormat
+		content string
+	}{
+		{
+			name:    "text",
+			format:  core.DictFormatText,
+			content: "# 注释\n赌场|gambling|severity=3|action=block\n骗子|scam\n",
+		},
+		{
+			name:   "json",
+			format: core.DictFormatJSON,
+			content: `[
+				{"word": "赌场", "category": "gambling", "severity": 3, "action": "block"},
+				{"word": "骗子", "category": "诈骗"}
+			]`,
+		},
+		{
+			name:    "csv",
+			format:  core.DictFormatCSV,
+			content: "word,category,severity,action\n赌场,gambling,3,block\n骗子,scam,,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := NewLoader()
+			err := loader.LoadFrom(context.Background(), strings.NewReader(tt.content), tt.format)
+			assert.NoError(t, err)
+
+			words := loader.GetWords()
+			assert.Equal(t, category.Gambling, words["赌场"])
+			assert.Equal(t, category.Scam, words["骗子"])
+
+			meta, ok := loader.GetWordMeta("赌场")
+			assert.True(t, ok)
+			assert.Equal(t, 3, meta.Severity)
+			assert.Equal(t, "block", meta.Action)
+
+			_, ok = loader.GetWordMeta("骗子")
+			assert.False(t, ok)
+		})
+	}
+}
+
+// TestLoadFromInvalid 测试格式错误时词库保持不变
+func TestLoadFromInvalid(t *testing.T) {
+	loader := NewLoader()
+	_ = loader.AddWord("原有词", category.Political)
+
+	err := loader.LoadFrom(context.Background(), strings.NewReader("新词1\n新词2|unknown|severity=1\n"), core.DictFormatText)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+
+	words := loader.GetWords()
+	assert.Len(t, words, 1)
+	_, exists := words["新词1"]
+	assert.False(t, exists)
+
+	err = loader.LoadFrom(context.Background(), strings.NewReader("{"), core.DictFormatJSON)
+	assert.Error(t, err)
+
+	err = loader.LoadFrom(context.Background(), strings.NewReader("category\nscam\n"), core.DictFormatCSV)
+	assert.Error(t, err)
+
+	err = loader.LoadFrom(context.Background(), strings.NewReader("x"), core.DictFormat("yaml"))
+	assert.Error(t, err)
+}
+
+// TestLoadFile 测试从文件加载词库
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gambling.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("赌场\n骗子|scam|severity=2\n"), 0o644))
+
+	loader := NewLoader()
+	assert.NoError(t, loader.LoadFile(context.Background(), path))
+
+	words := loader.GetWords()
+	assert.Equal(t, category.Gambling, words["赌场"])
+	assert.Equal(t, category.Scam, words["骗子"])
+
+	assert.Error(t, loader.LoadFile(context.Background(), filepath.Join(dir, "missing.txt")))
+}
+
+// TestMetaRemoved 测试删除词条时同时删除元数据
+func TestMetaRemoved(t *testing.T) {
+	loader := NewLoader()
+	assert.NoError(t, loader.AddWordWithMeta("赌场", category.Gambling, core.WordMeta{Severity: 3}))
+
+	_, ok := loader.GetWordMeta("赌场")
+	assert.True(t, ok)
+
+	assert.NoError(t, loader.RemoveWord("赌场"))
+	_, ok = loader.GetWordMeta("赌场")
+	assert.False(t, ok)
+}
+
+// TestExport 测试导出词库
+func TestExport(t *testing.T) {
+	loader := NewLoader()
+	assert.NoError(t, loader.AddWords(map[string]category.Category{
+		"老千": category.Gambling,
+		"赌场": category.Gambling,
+		"骗子": category.Scam,
+	}))
+	assert.NoError(t, loader.AddWordWithMeta("下注", category.Gambling, core.WordMeta{
+		Severity: 2,
+		Action:   "review",
+		Tags:     []string{"线上", "高危"},
+	}))
+	// 另一来源贡献的分类使 下注 同时属于两个分类
+	assert.NoError(t, loader.AddWordsAs("ops", map[string]category.Category{"下注": category.Scam}))
+
+	var buf bytes.Buffer
+	assert.NoError(t, loader.Export(&buf, core.DictFormatText))
+	assert.Equal(t, "# 赌博\n"+
+		"下注|赌博|severity=2|action=review|tags=线上,高危\n"+
+		"老千|赌博\n"+
+		"赌场|赌博\n"+
+		"\n# 诈骗\n"+
+		"下注|诈骗|severity=2|action=review|tags=线上,高危\n"+
+		"骗子|诈骗\n", buf.String())
+
+	for _, format := range []core.DictFormat{core.DictFormatText, core.DictFormatJSON, core.DictFormatCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			var first, second bytes.Buffer
+			assert.NoError(t, loader.Export(&first, format))
+			assert.NoError(t, loader.Export(&second, format))
+			assert.Equal(t, first.String(), second.String())
+
+			// 导出结果可被重新加载
+			restored := NewLoader()
+			assert.NoError(t, restored.LoadFrom(context.Background(), &first, format))
+			assert.Equal(t, loader.GetWords(), restored.GetWords())
+			assert.Equal(t, loader.getAllMeta(), restored.getAllMeta())
+			assert.Equal(t, []category.Category{category.Gambling, category.Scam}, restored.GetWordCategories("下注"))
+		})
+	}
+
+	assert.Error(t, loader.Export(&buf, "xml"))
+}
+
+// TestExportUnrepresentable 测试文本格式无法表示的词条
+func TestExportUnrepresentable(t *testing.T) {
+	for _, word := range []string{"#话题", "a|b"} {
+		loader := NewLoader()
+		assert.NoError(t, loader.AddWord(word, category.Custom))
+		assert.Error(t, loader.Export(&bytes.Buffer{}, core.DictFormatText), word)
+		assert.NoError(t, loader.Export(&bytes.Buffer{}, core.DictFormatJSON), word)
+	}
+}
+
+// TestExportDir 测试按分类导出为与内置词库相同的目录布局
+func TestExportDir(t *testing.T) {
+	reg := category.NewRegistry()
+	firearms, err := reg.RegisterSub(category.Violence, "枪支")
+	assert.NoError(t, err)
+	assert.NoError(t, reg.SetID(firearms, "violence/firearms"))
+
+	loader := NewLoader()
+	loader.SetRegistry(reg)
+	assert.NoError(t, loader.AddWordsAs("alice", map[string]category.Category{
+		"赌场":  category.Gambling,
+		"老千":  category.Gambling,
+		"猎枪":  firearms,
+		"通用词": category.None,
+	}))
+	assert.NoError(t, loader.AddWordsAs("bob", map[string]category.Category{"老千": category.Scam}))
+	assert.NoError(t, loader.AddWordWithMeta("骗子", category.Scam, core.WordMeta{Severity: 3}))
+
+	dir := t.TempDir()
+	assert.NoError(t, loader.ExportDir(dir))
+
+	files := map[string]string{
+		"gambling.txt":          "老千\n赌场\n",
+		"scam.txt":              "老千\n骗子\n",
+		"violence/firearms.txt": "猎枪\n",
+		"all.txt":               "通用词\n",
+	}
+	for name, want := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		assert.NoError(t, err, name)
+		assert.Equal(t, want, string(data), name)
+	}
+
+	// 逐个加载导出的文件可还原词条与分类
+	restored := NewLoader()
+	restored.SetRegistry(reg)
+	for name := range files {
+		assert.NoError(t, restored.LoadFile(context.Background(), filepath.Join(dir, filepath.FromSlash(name))))
+	}
+	assert.Equal(t, loader.GetWords(), restored.GetWords())
+	assert.Equal(t, []category.Category{category.Gambling, category.Scam}, restored.GetWordCategories("老千"))
+
+	// 无法按原样读回的词条导致导出失败，且不写入任何文件
+	bad := NewLoader()
+	assert.NoError(t, bad.AddWords(map[string]category.Category{"赌场": category.Gambling, "a|gambling": category.Custom}))
+	empty := t.TempDir()
+	assert.Error(t, bad.ExportDir(empty))
+	entries, err := os.ReadDir(empty)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+// TestLoaderRegistry 测试加载器使用独立的分类注册表
+func TestLoaderRegistry(t *testing.T) {
+	reg := category.NewRegistry()
+	ai, err := reg.Register("AI相关")
+	assert.NoError(t, err)
+
+	// 默认注册表不认识该分类，整行按原词加载
+	loader := NewLoader()
+	ctx := context.Background()
+	assert.NoError(t, loader.LoadFrom(ctx, strings.NewReader("大模型|AI相关\n"), core.DictFormatText))
+	assert.Contains(t, loader.GetWords(), "大模型|AI相关")
+
+	loader = NewLoader()
+	loader.SetRegistry(reg)
+	assert.NoError(t, loader.LoadFrom(ctx, strings.NewReader("大模型|AI相关\n"), core.DictFormatText))
+	assert.Equal(t, ai, loader.GetWords()["大模型"])
+
+	var buf bytes.Buffer
+	assert.NoError(t, loader.Export(&buf, core.DictFormatText))
+	assert.Equal(t, "# AI相关\n大模型|AI相关\n", buf.String())
+
+	// 其他加载器不受影响
+	assert.Error(t, NewLoader().AddWord("大模型", ai))
+}
@@ -1,11 +1,17 @@
 package dictionary
 
 import (
-	"bufio"
+	"bytes"
 	"context"
 	_ "embed"
 	"fmt"
 	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -18,10 +24,24 @@ import (
 // Loader 实现core.Loader接口
 type Loader struct {
 	words           sync.Map
+	meta            sync.Map // word => core.WordMeta
 	observers       sync.Map
+	registry        atomic.Pointer[category.Registry] // 分类注册表
+	writeMu         sync.Mutex                        // 串行化所有写操作
+	history         history                           // 版本历史，受 writeMu 保护
+	undo            map[string]wordSnapshot           // 当前写操作修改前的词条状态，失败时用于撤销，受 writeMu 保护
+	notifySeq       uint64                            // 通知快照序号，受 writeMu 保护
+	notifyMu        sync.Mutex
+	notifyPending   *notification // 尚未送达的最新通知，受 notifyMu 保护
+	notifying       bool          // 是否有协程正在通知观察者，受 notifyMu 保护
+	sourcesMu       sync.Mutex
+	sources         map[core.WordSource]map[string]struct{} // 各来源贡献的词条
+	provenance      map[string]map[string]*category.Set     // 词条 => 来源 => 分类，受 writeMu 保护
 	notifyBatchSize int
 	lastNotifyTime  atomic.Value // time.Time
 	notifyInterval  time.Duration
+	inst            atomic.Pointer[core.Instrumentation] // 指标采集，未设置时为 nil
+	logger          atomic.Pointer[slog.Logger]          // 结构化日志，未设置时使用 slog.Default()
 }
 
 // NewLoader 创建新的加载器实例
@@ -29,11 +49,27 @@ func NewLoader() *Loader {
 	l := &Loader{
 		notifyBatchSize: 100,
 		notifyInterval:  time.Millisecond * 100,
+		history:         newHistory(defaultHistoryLimit),
+		provenance:      make(map[string]map[string]*category.Set),
 	}
 	l.lastNotifyTime.Store(time.Now())
+	l.registry.Store(category.Default)
 	return l
 }
 
+// Registry 返回解析与校验分类所用的注册表
+func (l *Loader) Registry() *category.Registry {
+	return l.registry.Load()
+}
+
+// SetRegistry 设置分类注册表，nil 表示使用 category.Default
+func (l *Loader) SetRegistry(reg *category.Registry) {
+	if reg == nil {
+		reg = category.Default
+	}
+	l.registry.Store(reg)
+}
+
 //go:embed default/political.txt
 var politicalWords string
 
@@ -63,6 +99,13 @@ var allWords string
 
 // LoadDefaultWords 加载默认词库
 func (l *Loader) LoadDefaultWords(ctx context.Context) error {
+	return l.write(DefaultActor, true, func() error {
+		return l.loadDefaultWords(ctx)
+	})
+}
+
+// loadDefaultWords 加载内置的分类词典和通用词典，调用方需持有 writeMu
+func (l *Loader) loadDefaultWords(ctx context.Context) error {
 	// 加载所有分类词典
 	categories := map[string]struct {
 		content string
@@ -83,44 +126,197 @@ func (l *Loader) LoadDefaultWords(ctx context.Context) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			if err := l.loadFromString(ctx, data.content, data.cat); err != nil {
+			if err := l.loadFrom(ctx, strings.NewReader(data.content), core.DictFormatText, data.cat, DefaultSource); err != nil {
 				return fmt.Errorf("failed to load %s: %w", filename, err)
 			}
 		}
 	}
 
 	// 加载通用词典
-	if err := l.loadFromString(ctx, allWords, category.None); err != nil {
+	if err := l.loadFrom(ctx, strings.NewReader(allWords), core.DictFormatText, category.None, DefaultSource); err != nil {
 		return fmt.Errorf("failed to load all.txt: %w", err)
 	}
-
-	l.notifyObserversIfNeeded(true)
 	return nil
 }
 
 // LoadCustomWords 加载自定义词库
 func (l *Loader) LoadCustomWords(ctx context.Context, words map[string]category.Category) error {
 	const batchSize = 1000
-	count := 0
 
-	for word, cat := range words {
-		if count%batchSize == 0 {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			default:
+	return l.write(DefaultActor, true, func() error {
+		count := 0
+		for word, cat := range words {
+			if count%batchSize == 0 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				default:
+				}
+			}
+			if err := l.addWordInternal(DefaultActor, word, cat); err != nil {
+				return err
 			}
+			count++
+		}
+		return nil
+	})
+}
+
+// LoadFrom 按指定格式从 Reader 加载词库，内容全部解析成功后才会写入
+func (l *Loader) LoadFrom(ctx context.Context, r io.Reader, format core.DictFormat) error {
+	return l.write(DefaultActor, true, func() error {
+		return l.loadFrom(ctx, r, format, category.None, DefaultActor)
+	})
+}
+
+// LoadFile 加载词库文件，格式由扩展名决定，文本格式的默认分类由文件名决定
+func (l *Loader) LoadFile(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return l.write(DefaultActor, true, func() error {
+		if err := l.loadFrom(ctx, f, formatFromPath(path), categoryFromPath(l.Registry(), path), "file:"+path); err != nil {
+			return fmt.Errorf("failed to load %s: %w", filepath.Base(path), err)
+		}
+		return nil
+	})
+}
+
+// Export 按指定格式导出当前词库，输出按分类、词条排序
+//
+// 文本格式输出到单个文件，每行显式写出分类和元数据；需要与内置词库相同的按分类布局时使用 ExportDir。
+// 属于多个分类的词条每个分类输出一条（元数据相同），重新加载时合并为同一词条。
+func (l *Loader) Export(w io.Writer, format core.DictFormat) error {
+	l.writeMu.Lock()
+	var entries []entry
+	l.words.Range(func(key, value interface{}) bool {
+		word := key.(string)
+		var meta core.WordMeta
+		if val, ok := l.meta.Load(word); ok {
+			meta = val.(core.WordMeta)
+		}
+		cats := l.mergedCategories(word)
+		if len(cats) == 0 {
+			cats = []category.Category{value.(category.Category)}
 		}
-		if err := l.addWordInternal(word, cat); err != nil {
+		for _, cat := range cats {
+			entries = append(entries, entry{word: word, cat: cat, meta: meta})
+		}
+		return true
+	})
+	l.writeMu.Unlock()
+
+	sortEntries(entries)
+	return encodeEntries(w, format, l.Registry(), entries)
+}
+
+// ExportDir 按分类将词库导出到目录，布局与内置词库（default 目录）一致
+//
+// 每个分类一个文本文件，以分类的 ASCII 标识命名（如 gambling.txt，子分类为 violence/firearms.txt），
+// 未分类的词条写入 all.txt；文件中每行一个不带标注的词条，按词条排序。属于多个分类的词条出现在
+// 每个分类的文件中。元数据不会导出，需要保留元数据时使用 Export 的 JSON 或 CSV 格式。
+// 导出的文件可通过 LoadFile 或 Watcher 逐个重新加载。
+func (l *Loader) ExportDir(dir string) error {
+	reg := l.Registry()
+
+	l.writeMu.Lock()
+	byCategory := make(map[category.Category][]string)
+	for word := range l.provenance {
+		cats := l.mergedCategories(word)
+		if len(cats) == 0 {
+			cats = []category.Category{category.None}
+		}
+		for _, cat := range cats {
+			byCategory[cat] = append(byCategory[cat], word)
+		}
+	}
+	l.writeMu.Unlock()
+
+	// 全部编码成功后再写入，避免留下不完整的导出
+	files := make(map[string][]byte, len(byCategory))
+	for cat, words := range byCategory {
+		sort.Strings(words)
+		var buf bytes.Buffer
+		if err := encodeWordList(&buf, reg, cat, words); err != nil {
+			return err
+		}
+		files[filepath.Join(dir, categoryFile(reg, cat))] = buf.Bytes()
+	}
+	for path, data := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
 			return err
 		}
-		count++
 	}
+	return nil
+}
 
-	l.notifyObserversIfNeeded(true)
+// loadFrom 解析并以指定来源写入词条
+func (l *Loader) loadFrom(ctx context.Context, r io.Reader, format core.DictFormat, defaultCat category.Category, source string) error {
+	entries, err := decodeEntries(ctx, r, format, l.Registry(), defaultCat)
+	if err != nil {
+		return err
+	}
+	byWord, order, err := l.groupEntries(entries)
+	if err != nil {
+		return err
+	}
+	for _, word := range order {
+		l.put(source, word, byWord[word])
+	}
 	return nil
 }
 
+// groupEntries 校验词条并按词分组，order 为词首次出现的顺序
+func (l *Loader) groupEntries(entries []entry) (byWord map[string][]entry, order []string, err error) {
+	byWord = make(map[string][]entry, len(entries))
+	for _, e := range entries {
+		if err := l.validate(e); err != nil {
+			return nil, nil, err
+		}
+		word := strings.TrimSpace(e.word)
+		if _, ok := byWord[word]; !ok {
+			order = append(order, word)
+		}
+		byWord[word] = append(byWord[word], e)
+	}
+	return byWord, order, nil
+}
+
+// applyChanges 以一个版本替换指定来源贡献的词条
+//
+// remove 中的词条和 add 中的词条都会先撤销该来源此前贡献的分类，再写入 add，
+// 其他来源贡献的分类保持不变；添加的词条会覆盖原有元数据。
+func (l *Loader) applyChanges(actor, source string, remove []string, add []entry) error {
+	return l.write(actor, true, func() error {
+		byWord, order, err := l.groupEntries(add)
+		if err != nil {
+			return err
+		}
+
+		for _, word := range remove {
+			if _, ok := byWord[word]; !ok {
+				l.track(word, func() { l.dropSource(word, source) })
+			}
+		}
+		for _, word := range order {
+			l.track(word, func() {
+				l.dropSource(word, source)
+				for _, e := range byWord[word] {
+					e.replaceMeta = true
+					l.contribute(source, word, e)
+				}
+			})
+		}
+		return nil
+	})
+}
+
 // AddObserver 添加观察者
 func (l *Loader) AddObserver(observer core.Observer) {
 	l.observers.Store(observer, struct{}{})
@@ -131,89 +327,409 @@ func (l *Loader) RemoveObserver(observer core.Observer) {
 	l.observers.Delete(observer)
 }
 
-// notifyObserversIfNeeded 根据条件通知观察者
-func (l *Loader) notifyObserversIfNeeded(force bool) {
+// notification 一次通知观察者的词库快照
+type notification struct {
+	seq        uint64
+	version    uint64
+	words      map[string]category.Category
+	meta       map[string]core.WordMeta
+	categories map[string][]category.Category
+}
+
+// write 串行执行一次写操作
+//
+// fn 成功时其变更提交为一个新版本，失败（或 panic）时撤销 fn 已做的全部修改，不产生版本也不通知。
+// 观察者在释放 writeMu 之后才被通知，因此可以在回调中调用 Loader 的任意方法。
+func (l *Loader) write(actor string, force bool, fn func() error) error {
+	n, err := l.apply(actor, force, fn)
+	if n != nil {
+		l.notify(n)
+	}
+	return err
+}
+
+// apply 在 writeMu 内执行 fn 并提交，需要通知时返回通知快照
+func (l *Loader) apply(actor string, force bool, fn func() error) (*notification, error) {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	l.undo = make(map[string]wordSnapshot)
+	defer l.discard()
+
+	if err := fn(); err != nil {
+		return nil, err
+	}
+	l.commit(actor)
+	l.undo = nil
+	return l.snapshotIfNeeded(force), nil
+}
+
+// discard 撤销当前写操作尚未提交的修改，调用方需持有 writeMu
+func (l *Loader) discard() {
+	for word, snap := range l.undo {
+		snap.restore(l, word)
+	}
+	l.undo = nil
+	l.history.pending = nil
+}
+
+// snapshotIfNeeded 根据条件生成通知快照，调用方需持有 writeMu 并已提交变更
+func (l *Loader) snapshotIfNeeded(force bool) *notification {
 	if !force {
 		lastNotify := l.lastNotifyTime.Load().(time.Time)
 		if time.Since(lastNotify) < l.notifyInterval {
-			return
+			return nil
 		}
 	}
+	l.lastNotifyTime.Store(time.Now())
+
+	l.notifySeq++
+	return &notification{
+		seq:        l.notifySeq,
+		version:    l.history.version,
+		words:      l.GetWords(),
+		meta:       l.getAllMeta(),
+		categories: l.getMultiCategories(),
+	}
+}
+
+// notify 通知观察者，不持有 writeMu
+//
+// 同一时刻只有一个协程通知观察者，其间产生的通知合并为最新的一份随后送达，
+// 观察者不会在收到新快照之后又收到旧快照；回调中的写操作也会由该协程送达。
+func (l *Loader) notify(n *notification) {
+	l.notifyMu.Lock()
+	if l.notifyPending == nil || n.seq > l.notifyPending.seq {
+		l.notifyPending = n
+	}
+	if l.notifying {
+		l.notifyMu.Unlock()
+		return
+	}
+	l.notifying = true
+	for l.notifyPending != nil {
+		n := l.notifyPending
+		l.notifyPending = nil
+		l.notifyMu.Unlock()
+		l.deliver(n)
+		l.notifyMu.Lock()
+	}
+	l.notifying = false
+	l.notifyMu.Unlock()
+}
 
-	words := l.GetWords()
+// deliver 将快照送达所有观察者
+func (l *Loader) deliver(n *notification) {
+	l.log().Debug("dictionary changed", "version", n.version, "words", len(n.words))
+	l.observeDictionary(n.words, n.categories)
 	l.observers.Range(func(key, value interface{}) bool {
-		if observer, ok := key.(core.Observer); ok {
-			observer.OnWordsChanged(words)
+		if observer, ok := key.(core.MetaObserver); ok {
+			observer.OnMetaChanged(n.meta)
+		}
+		if observer, ok := key.(core.CategoriesObserver); ok {
+			observer.OnCategoriesChanged(n.categories)
+		}
+		if observer, ok := key.(core.VersionObserver); ok {
+			observer.OnVersionChanged(n.version, n.words)
+		} else if observer, ok := key.(core.Observer); ok {
+			observer.OnWordsChanged(n.words)
 		}
 		return true
 	})
+}
 
-	l.lastNotifyTime.Store(time.Now())
+// SetLogger 实现 core.LoggerSetter 接口，热加载和远程同步也使用该日志记录器
+func (l *Loader) SetLogger(logger *slog.Logger) {
+	l.logger.Store(logger)
+}
+
+// log 返回当前的日志记录器
+func (l *Loader) log() *slog.Logger {
+	if logger := l.logger.Load(); logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
+
+// SetInstrumentation 实现 Instrumented 接口，设置后立即报告一次当前词库大小
+func (l *Loader) SetInstrumentation(inst core.Instrumentation) {
+	if inst == nil {
+		l.inst.Store(nil)
+		return
+	}
+	l.inst.Store(&inst)
+
+	// 在写锁内取快照，避免与写操作并发读取来源记录
+	l.writeMu.Lock()
+	words, categories := l.GetWords(), l.getMultiCategories()
+	l.writeMu.Unlock()
+	l.observeDictionary(words, categories)
+}
+
+// observeDictionary 向指标采集报告词库总数和各分类的词条数
+func (l *Loader) observeDictionary(words map[string]category.Category, categories map[string][]category.Category) {
+	p := l.inst.Load()
+	if p == nil {
+		return
+	}
+	perCategory := make(map[category.Category]int)
+	for word, cat := range words {
+		if cats, ok := categories[word]; ok {
+			for _, c := range cats {
+				perCategory[c]++
+			}
+			continue
+		}
+		perCategory[cat]++
+	}
+	(*p).ObserveDictionary(len(words), perCategory)
 }
 
 // AddWord 添加单个敏感词
 func (l *Loader) AddWord(word string, cat category.Category) error {
-	if err := l.addWordInternal(word, cat); err != nil {
+	return l.write(DefaultActor, false, func() error {
+		return l.addWordInternal(DefaultActor, word, cat)
+	})
+}
+
+// AddWordWithMeta 添加带元数据的敏感词
+func (l *Loader) AddWordWithMeta(word string, cat category.Category, meta core.WordMeta) error {
+	return l.write(DefaultActor, false, func() error {
+		return l.addEntryInternal(DefaultActor, entry{word: word, cat: cat, meta: meta})
+	})
+}
+
+// addWordInternal 内部添加词方法
+func (l *Loader) addWordInternal(source, word string, cat category.Category) error {
+	return l.addEntryInternal(source, entry{word: word, cat: cat})
+}
+
+// addEntryInternal 内部添加词条方法并记录变更，调用方需持有 writeMu
+func (l *Loader) addEntryInternal(source string, e entry) error {
+	if err := l.validate(e); err != nil {
 		return err
 	}
-	l.notifyObserversIfNeeded(false)
+	l.put(source, strings.TrimSpace(e.word), []entry{e})
 	return nil
 }
 
-// addWordInternal 内部添加词方法
-func (l *Loader) addWordInternal(word string, cat category.Category) error {
-	if word = strings.TrimSpace(word); word == "" {
+// put 以来源写入同一词条的若干条目并记录变更，调用方需持有 writeMu
+//
+// 同一来源再次写入时替换其此前贡献的分类（仅含 None 时保留原分类），
+// 不同来源贡献的分类合并；元数据非空或 replaceMeta 为 true 时覆盖已有元数据。
+func (l *Loader) put(source, word string, entries []entry) {
+	l.track(word, func() {
+		for _, e := range entries {
+			if e.cat != category.None {
+				l.dropSource(word, source)
+				break
+			}
+		}
+		for _, e := range entries {
+			l.contribute(source, word, e)
+		}
+	})
+}
+
+// validate 校验词条
+func (l *Loader) validate(e entry) error {
+	if strings.TrimSpace(e.word) == "" {
 		return fmt.Errorf("word cannot be empty")
 	}
-
 	// 验证分类的有效性
-	if !cat.IsValid() {
-		return fmt.Errorf("invalid category: %v", cat)
+	if !l.Registry().IsValid(e.cat) {
+		return fmt.Errorf("invalid category: %v", e.cat)
 	}
+	return nil
+}
 
-	// 如果词已存在且有效分类，且当前要设置的是 None 分类，则保留原有分类
-	if val, exists := l.words.Load(word); exists {
-		if existingCat, ok := val.(category.Category); ok && existingCat != category.None && cat == category.None {
-			return nil
+// contribute 记录来源为词条贡献的分类并更新元数据，调用方需在 track 中调用
+func (l *Loader) contribute(source, word string, e entry) {
+	sources := l.provenance[word]
+	if sources == nil {
+		sources = make(map[string]*category.Set)
+		l.provenance[word] = sources
+	}
+	set := sources[source]
+	if set == nil {
+		set = category.NewSet()
+		sources[source] = set
+	}
+	// None 不会加入集合，仅表示该来源包含此词条
+	set.Add(e.cat)
+
+	switch {
+	case !e.meta.IsZero():
+		l.meta.Store(word, e.meta)
+	case e.replaceMeta:
+		l.meta.Delete(word)
+	}
+}
+
+// dropSource 撤销来源为词条贡献的分类，调用方需在 track 中调用
+func (l *Loader) dropSource(word, source string) {
+	if sources, ok := l.provenance[word]; ok {
+		delete(sources, source)
+	}
+}
+
+// track 执行对单个词条的修改，同步词库并记录变更，调用方需持有 writeMu
+//
+// 修改后没有任何来源的词条会被删除。
+func (l *Loader) track(word string, fn func()) {
+	if _, saved := l.undo[word]; !saved && l.undo != nil {
+		l.undo[word] = l.snapshotWord(word)
+	}
+	before, existed := l.stateOf(word)
+	fn()
+
+	if sources, ok := l.provenance[word]; ok && len(sources) > 0 {
+		l.words.Store(word, primaryOf(l.mergedCategories(word)))
+	} else {
+		delete(l.provenance, word)
+		l.words.Delete(word)
+		l.meta.Delete(word)
+	}
+
+	after, exists := l.stateOf(word)
+	switch {
+	case existed && !exists:
+		l.history.record(Change{Op: OpRemove, Word: word, Category: before.Category, Categories: before.Categories})
+	case exists && (!existed || !reflect.DeepEqual(before, after)):
+		l.history.record(Change{Op: OpAdd, Word: word, Category: after.Category, Categories: after.Categories, Meta: after.Meta})
+	}
+}
+
+// wordSnapshot 词条在写操作开始前的存储状态
+type wordSnapshot struct {
+	cat     category.Category
+	hasCat  bool
+	meta    core.WordMeta
+	hasMeta bool
+	sources map[string]*category.Set // nil 表示没有任何来源
+}
+
+// snapshotWord 记录词条当前的存储状态，调用方需持有 writeMu
+func (l *Loader) snapshotWord(word string) wordSnapshot {
+	var snap wordSnapshot
+	if val, ok := l.words.Load(word); ok {
+		snap.cat, snap.hasCat = val.(category.Category), true
+	}
+	snap.meta, snap.hasMeta = l.GetWordMeta(word)
+	if sources, ok := l.provenance[word]; ok {
+		snap.sources = make(map[string]*category.Set, len(sources))
+		for source, set := range sources {
+			snap.sources[source] = set.Clone()
 		}
 	}
+	return snap
+}
 
-	l.words.Store(word, cat)
-	return nil
+// restore 将词条恢复为快照中的状态，调用方需持有 writeMu
+func (snap wordSnapshot) restore(l *Loader, word string) {
+	if snap.hasCat {
+		l.words.Store(word, snap.cat)
+	} else {
+		l.words.Delete(word)
+	}
+	if snap.hasMeta {
+		l.meta.Store(word, snap.meta)
+	} else {
+		l.meta.Delete(word)
+	}
+	if snap.sources != nil {
+		l.provenance[word] = snap.sources
+	} else {
+		delete(l.provenance, word)
+	}
+}
+
+// stateOf 返回词条当前状态，调用方需持有 writeMu
+func (l *Loader) stateOf(word string) (WordState, bool) {
+	val, ok := l.words.Load(word)
+	if !ok {
+		return WordState{}, false
+	}
+	ws := WordState{Category: val.(category.Category)}
+	if cats := l.mergedCategories(word); len(cats) > 1 {
+		ws.Categories = cats
+	}
+	ws.Meta, _ = l.GetWordMeta(word)
+	return ws, true
+}
+
+// mergedCategories 合并所有来源贡献的分类，调用方需持有 writeMu
+func (l *Loader) mergedCategories(word string) []category.Category {
+	merged := category.NewSet()
+	for _, set := range l.provenance[word] {
+		merged.Union(set)
+	}
+	return merged.Categories()
+}
+
+// primaryOf 返回序号最小的分类作为主分类
+func primaryOf(cats []category.Category) category.Category {
+	if len(cats) == 0 {
+		return category.None
+	}
+	return cats[0]
+}
+
+// removeWordInternal 内部删除词方法并记录变更，调用方需持有 writeMu
+func (l *Loader) removeWordInternal(word string) {
+	l.track(word, func() { delete(l.provenance, word) })
 }
 
 // AddWords 批量添加敏感词
 func (l *Loader) AddWords(words map[string]category.Category) error {
-	for word, cat := range words {
-		if err := l.addWordInternal(word, cat); err != nil {
-			return err
+	return l.AddWordsAs(DefaultActor, words)
+}
+
+// AddWordsAs 以指定操作者身份批量添加敏感词
+func (l *Loader) AddWordsAs(actor string, words map[string]category.Category) error {
+	return l.write(actor, true, func() error {
+		for word, cat := range words {
+			if err := l.addWordInternal(actor, word, cat); err != nil {
+				return err
+			}
 		}
-	}
-	l.notifyObserversIfNeeded(true)
-	return nil
+		return nil
+	})
 }
 
 // RemoveWord 移除单个敏感词
 func (l *Loader) RemoveWord(word string) error {
-	l.words.Delete(word)
-	l.notifyObserversIfNeeded(false)
-	return nil
+	return l.write(DefaultActor, false, func() error {
+		l.removeWordInternal(word)
+		return nil
+	})
 }
 
 // RemoveWords 批量移除敏感词
 func (l *Loader) RemoveWords(words []string) error {
-	for _, word := range words {
-		l.words.Delete(word)
-	}
-	l.notifyObserversIfNeeded(true)
-	return nil
+	return l.RemoveWordsAs(DefaultActor, words)
+}
+
+// RemoveWordsAs 以指定操作者身份批量移除敏感词
+func (l *Loader) RemoveWordsAs(actor string, words []string) error {
+	return l.write(actor, true, func() error {
+		for _, word := range words {
+			l.removeWordInternal(word)
+		}
+		return nil
+	})
 }
 
 // Clear 清空所有敏感词
 func (l *Loader) Clear() error {
-	l.words = sync.Map{}
-	l.notifyObserversIfNeeded(true)
-	return nil
+	return l.write(DefaultActor, true, func() error {
+		l.words.Range(func(key, value interface{}) bool {
+			if word, ok := key.(string); ok {
+				l.removeWordInternal(word)
+			}
+			return true
+		})
+		return nil
+	})
 }
 
 // loadFromString 从字符串加载敏感词
@@ -224,29 +740,7 @@ func (l *Loader) loadFromString(ctx context.Context, content string, cat categor
 
 // loadFromReader 从Reader加载敏感词
 func (l *Loader) loadFromReader(ctx context.Context, reader io.Reader, cat category.Category) error {
-	scanner := bufio.NewScanner(reader)
-	const batchSize = 1000
-	count := 0
-
-	for scanner.Scan() {
-		if count%batchSize == 0 {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			default:
-			}
-		}
-
-		word := strings.TrimSpace(scanner.Text())
-		if word == "" || strings.HasPrefix(word, "#") {
-			continue
-		}
-		if err := l.addWordInternal(word, cat); err != nil {
-			return err
-		}
-		count++
-	}
-	return scanner.Err()
+	return l.loadFrom(ctx, reader, core.DictFormatText, cat, DefaultActor)
 }
 
 // GetWords 获取所有已加载的敏感词
@@ -262,3 +756,82 @@ func (l *Loader) GetWords() map[string]category.Category {
 	})
 	return words
 }
+
+// GetWordMeta 获取词条元数据
+func (l *Loader) GetWordMeta(word string) (core.WordMeta, bool) {
+	if val, ok := l.meta.Load(word); ok {
+		if meta, ok := val.(core.WordMeta); ok {
+			return meta, true
+		}
+	}
+	return core.WordMeta{}, false
+}
+
+// GetWordCategories 获取词条的全部分类，词条不存在时返回 nil
+func (l *Loader) GetWordCategories(word string) []category.Category {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	if _, ok := l.provenance[word]; !ok {
+		return nil
+	}
+	if cats := l.mergedCategories(word); len(cats) > 0 {
+		return cats
+	}
+	return []category.Category{category.None}
+}
+
+// Provenance 返回各来源为词条贡献的分类
+//
+// 来源为操作者（默认为 system）、default（内置词库）、file:路径、watcher:路径或 source:名称。
+func (l *Loader) Provenance(word string) map[string][]category.Category {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	sources, ok := l.provenance[word]
+	if !ok {
+		return nil
+	}
+	result := make(map[string][]category.Category, len(sources))
+	for source, set := range sources {
+		result[source] = set.Categories()
+	}
+	return result
+}
+
+// Snapshot 返回当前词库的完整快照，包含词条、多分类和元数据，Version 为十进制的词库版本
+//
+// 快照与观察者收到的通知内容一致，可用于在注册观察者之前初始化其状态。
+func (l *Loader) Snapshot() *core.WordSnapshot {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	return &core.WordSnapshot{
+		Version:    strconv.FormatUint(l.history.version, 10),
+		Words:      l.GetWords(),
+		Meta:       l.getAllMeta(),
+		Categories: l.getMultiCategories(),
+	}
+}
+
+// getMultiCategories 获取所有属于多个分类的词条，调用方需持有 writeMu
+func (l *Loader) getMultiCategories() map[string][]category.Category {
+	result := make(map[string][]category.Category)
+	for word := range l.provenance {
+		if cats := l.mergedCategories(word); len(cats) > 1 {
+			result[word] = cats
+		}
+	}
+	return result
+}
+
+// getAllMeta 获取所有词条元数据
+func (l *Loader) getAllMeta() map[string]core.WordMeta {
+	meta := make(map[string]core.WordMeta)
+	l.meta.Range(func(key, value interface{}) bool {
+		if k, ok := key.(string); ok {
+			if v, ok := value.(core.WordMeta); ok {
+				meta[k] = v
+			}
+		}
+		return true
+	})
+	return meta
+}
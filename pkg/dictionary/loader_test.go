@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/ttofTnT/go-swd/pkg/core"
 	"github.com/ttofTnT/go-swd/pkg/types/category"
 )
 
@@ -315,3 +316,19 @@ type ErrorReader struct {
 func (r *ErrorReader) Read(p []byte) (n int, err error) {
 	return 0, r.err
 }
+
+// TestSetInstrumentationConcurrent 测试设置指标采集与写操作并发执行（配合 -race 检查）
+func TestSetInstrumentationConcurrent(t *testing.T) {
+	loader := NewLoader()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = loader.AddWordsAs(fmt.Sprintf("actor%d", i%3), map[string]category.Category{"赌场": category.Gambling})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		loader.SetInstrumentation(core.NopInstrumentation{})
+	}
+	<-done
+}
@@ -0,0 +1,352 @@
+package dictionary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// ErrInvalidInterval 同步间隔必须大于 0
+var ErrInvalidInterval = errors.New("poll interval must be positive")
+
+// HTTPSource 通过 HTTP 拉取词库，支持 ETag/If-Modified-Since 条件请求和失败重试
+//
+// 响应体格式由 Content-Type 决定（application/json、text/csv），
+// 无法识别时使用 WithFormat 指定的格式，默认为文本格式。
+type HTTPSource struct {
+	url         string
+	client      *http.Client
+	format      core.DictFormat
+	registry    *category.Registry
+	defaultCat  category.Category
+	maxAttempts int
+	backoff     time.Duration
+
+	mu           sync.Mutex
+	etag         string
+	lastModified string
+	pending      *pendingValidators // 已拉取但尚未确认应用的快照的版本标识
+}
+
+// pendingValidators 尚未确认应用的快照及其条件请求标识
+type pendingValidators struct {
+	snapshot     *core.WordSnapshot
+	etag         string
+	lastModified string
+}
+
+// HTTPSourceOption HTTP 词库来源配置项
+type HTTPSourceOption func(*HTTPSource)
+
+// WithHTTPClient 设置 HTTP 客户端
+func WithHTTPClient(client *http.Client) HTTPSourceOption {
+	return func(s *HTTPSource) {
+		if client != nil {
+			s.client = client
+		}
+	}
+}
+
+// WithFormat 设置无法从 Content-Type 识别时使用的词库格式
+func WithFormat(format core.DictFormat) HTTPSourceOption {
+	return func(s *HTTPSource) {
+		s.format = format
+	}
+}
+
+// WithDefaultCategory 设置未指定分类的词条所用分类
+func WithDefaultCategory(cat category.Category) HTTPSourceOption {
+	return func(s *HTTPSource) {
+		s.defaultCat = cat
+	}
+}
+
+// WithRegistry 设置解析分类名称所用的注册表，默认为 category.Default
+func WithRegistry(reg *category.Registry) HTTPSourceOption {
+	return func(s *HTTPSource) {
+		if reg != nil {
+			s.registry = reg
+		}
+	}
+}
+
+// WithRetry 设置最大尝试次数与初始退避时长，退避时长每次翻倍
+func WithRetry(maxAttempts int, backoff time.Duration) HTTPSourceOption {
+	return func(s *HTTPSource) {
+		if maxAttempts > 0 {
+			s.maxAttempts = maxAttempts
+		}
+		if backoff >= 0 {
+			s.backoff = backoff
+		}
+	}
+}
+
+// NewHTTPSource 创建 HTTP 词库来源
+func NewHTTPSource(url string, opts ...HTTPSourceOption) *HTTPSource {
+	s := &HTTPSource{
+		url:         url,
+		client:      &http.Client{Timeout: time.Second * 30},
+		format:      core.DictFormatText,
+		registry:    category.Default,
+		maxAttempts: 3,
+		backoff:     time.Millisecond * 500,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Name 返回来源名称，即词库地址
+func (s *HTTPSource) Name() string {
+	return s.url
+}
+
+// Version 返回最近一次确认应用的版本标识，优先使用 ETag
+func (s *HTTPSource) Version() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return versionOf(s.etag, s.lastModified)
+}
+
+// versionOf 返回版本标识，优先使用 ETag
+func versionOf(etag, lastModified string) string {
+	if etag != "" {
+		return etag
+	}
+	return lastModified
+}
+
+// Commit 实现 core.SnapshotCommitter 接口，确认快照已应用后才在后续请求中发送其版本标识
+//
+// 直接调用 Fetch 的使用方应在应用快照后调用 Commit，否则每次拉取都会返回完整内容。
+func (s *HTTPSource) Commit(snapshot *core.WordSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pending == nil || s.pending.snapshot != snapshot {
+		return
+	}
+	s.etag, s.lastModified = s.pending.etag, s.pending.lastModified
+	s.pending = nil
+}
+
+// Fetch 拉取词库快照，服务端返回 304 时返回 core.ErrNotModified
+//
+// 快照的版本标识在 Commit 之前不会用于条件请求。
+func (s *HTTPSource) Fetch(ctx context.Context) (*core.WordSnapshot, error) {
+	var lastErr error
+	backoff := s.backoff
+
+	for attempt := 0; attempt < s.maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		snapshot, retry, err := s.fetchOnce(ctx)
+		if err == nil || !retry {
+			return snapshot, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("fetch %s failed after %d attempts: %w", s.url, s.maxAttempts, lastErr)
+}
+
+// fetchOnce 发起一次请求，返回是否值得重试
+func (s *HTTPSource) fetchOnce(ctx context.Context) (*core.WordSnapshot, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.mu.Lock()
+	if s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+	if s.lastModified != "" {
+		req.Header.Set("If-Modified-Since", s.lastModified)
+	}
+	s.mu.Unlock()
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return nil, false, core.ErrNotModified
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, true, fmt.Errorf("unexpected status: %s", resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return nil, false, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	entries, err := decodeEntries(ctx, resp.Body, s.formatOf(resp), s.registry, s.defaultCat)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid dictionary from %s: %w", s.url, err)
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	snapshot := entriesToSnapshot(entries)
+	snapshot.Version = versionOf(etag, lastModified)
+
+	s.mu.Lock()
+	s.pending = &pendingValidators{snapshot: snapshot, etag: etag, lastModified: lastModified}
+	s.mu.Unlock()
+	return snapshot, false, nil
+}
+
+// formatOf 根据 Content-Type 判断词库格式
+func (s *HTTPSource) formatOf(resp *http.Response) core.DictFormat {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return s.format
+	}
+	switch mediaType {
+	case "application/json":
+		return core.DictFormatJSON
+	case "text/csv":
+		return core.DictFormatCSV
+	default:
+		return s.format
+	}
+}
+
+// entriesToSnapshot 将词条转换为快照，同一词条的多个分类合并，主分类为序号最小的分类
+func entriesToSnapshot(entries []entry) *core.WordSnapshot {
+	snapshot := &core.WordSnapshot{
+		Words:      make(map[string]category.Category, len(entries)),
+		Meta:       make(map[string]core.WordMeta),
+		Categories: make(map[string][]category.Category),
+	}
+	sets := make(map[string]*category.Set, len(entries))
+	for _, e := range entries {
+		set := sets[e.word]
+		if set == nil {
+			set = category.NewSet()
+			sets[e.word] = set
+		}
+		if e.cat != category.None {
+			set.Add(e.cat)
+		}
+		if !e.meta.IsZero() {
+			snapshot.Meta[e.word] = e.meta
+		}
+	}
+	for word, set := range sets {
+		cats := set.Categories()
+		snapshot.Words[word] = primaryOf(cats)
+		if len(cats) > 1 {
+			snapshot.Categories[word] = cats
+		}
+	}
+	return snapshot
+}
+
+// sourceName 返回来源名称，来源未实现 Name 方法时使用类型和地址
+func sourceName(src core.WordSource) string {
+	if named, ok := src.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return fmt.Sprintf("%T@%p", src, src)
+}
+
+// Sync 从词库来源拉取一次并替换该来源此前贡献的词条
+//
+// 拉取或校验失败时词库保持为上一份成功的快照，来源未变更时直接返回 nil。
+// 来源实现 core.SnapshotCommitter 时，快照应用成功后才调用其 Commit。
+func (l *Loader) Sync(ctx context.Context, src core.WordSource) error {
+	snapshot, err := src.Fetch(ctx)
+	if errors.Is(err, core.ErrNotModified) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return fmt.Errorf("word source returned nil snapshot")
+	}
+
+	entries := make([]entry, 0, len(snapshot.Words))
+	words := make(map[string]struct{}, len(snapshot.Words))
+	for word, cat := range snapshot.Words {
+		cats := snapshot.Categories[word]
+		if len(cats) == 0 {
+			cats = []category.Category{cat}
+		}
+		for _, cat := range cats {
+			entries = append(entries, entry{word: word, cat: cat, meta: snapshot.Meta[word]})
+		}
+		words[word] = struct{}{}
+	}
+
+	l.sourcesMu.Lock()
+	defer l.sourcesMu.Unlock()
+
+	var remove []string
+	for word := range l.sources[src] {
+		if _, ok := words[word]; !ok {
+			remove = append(remove, word)
+		}
+	}
+	if err := l.applyChanges("source:"+snapshot.Version, "source:"+sourceName(src), remove, entries); err != nil {
+		return err
+	}
+	if committer, ok := src.(core.SnapshotCommitter); ok {
+		committer.Commit(snapshot)
+	}
+	if l.sources == nil {
+		l.sources = make(map[core.WordSource]map[string]struct{})
+	}
+	l.sources[src] = words
+	return nil
+}
+
+// Poll 按固定间隔同步词库来源，直到 ctx 结束；同步失败时记录日志并通过 onError 上报
+//
+// interval 不大于 0 时立即返回 ErrInvalidInterval。
+func (l *Loader) Poll(ctx context.Context, src core.WordSource, interval time.Duration, onError func(error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidInterval, interval)
+	}
+
+	syncOnce := func() {
+		err := l.Sync(ctx, src)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		l.log().Warn("failed to sync word source", "source", sourceName(src), "error", err)
+		if onError != nil {
+			onError(err)
+		}
+	}
+
+	syncOnce()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			syncOnce()
+		}
+	}
+}
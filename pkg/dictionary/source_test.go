@@ -0,0 +1,226 @@
+package dictionary
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// lexiconServer 模拟发布词库的 HTTP 服务
+type lexiconServer struct {
+	mu       sync.Mutex
+	body     string
+	etag     string
+	failures int // 接下来需要返回 503 的次数
+	requests atomic.Int32
+}
+
+func (s *lexiconServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests.Add(1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.Header.Get("If-None-Match") == s.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", s.etag)
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(s.body))
+}
+
+func (s *lexiconServer) set(body, etag string, failures int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.body, s.etag, s.failures = body, etag, failures
+}
+
+// TestHTTPSourceFetch 测试条件请求与重试
+func TestHTTPSourceFetch(t *testing.T) {
+	lexicon := &lexiconServer{}
+	lexicon.set("赌场|gambling|severity=3\n骗子|scam\n", `"v1"`, 1)
+	server := httptest.NewServer(lexicon)
+	defer server.Close()
+
+	src := NewHTTPSource(server.URL, WithRetry(3, time.Millisecond))
+	ctx := context.Background()
+
+	snapshot, err := src.Fetch(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, `"v1"`, snapshot.Version)
+	assert.Equal(t, category.Gambling, snapshot.Words["赌场"])
+	assert.Equal(t, 3, snapshot.Meta["赌场"].Severity)
+	assert.Equal(t, int32(2), lexicon.requests.Load())
+
+	// 确认应用之前不发送条件请求
+	assert.Equal(t, "", src.Version())
+	snapshot, err = src.Fetch(ctx)
+	assert.NoError(t, err)
+	src.Commit(snapshot)
+	assert.Equal(t, `"v1"`, src.Version())
+
+	_, err = src.Fetch(ctx)
+	assert.ErrorIs(t, err, core.ErrNotModified)
+
+	lexicon.set("赌场\n", `"v2"`, 5)
+	_, err = src.Fetch(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, `"v1"`, src.Version())
+}
+
+// TestHTTPSourceFetchClientError 测试 4xx 不重试
+func TestHTTPSourceFetchClientError(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	src := NewHTTPSource(server.URL, WithRetry(3, time.Millisecond))
+	_, err := src.Fetch(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+// TestLoaderSync 测试同步来源并在失败时保留上一份快照
+func TestLoaderSync(t *testing.T) {
+	lexicon := &lexiconServer{}
+	lexicon.set("赌场|gambling\n老千|gambling\n", `"v1"`, 0)
+	server := httptest.NewServer(lexicon)
+	defer server.Close()
+
+	loader := NewLoader()
+	_ = loader.AddWord("本地词", category.Political)
+	src := NewHTTPSource(server.URL, WithRetry(2, time.Millisecond))
+	ctx := context.Background()
+
+	assert.NoError(t, loader.Sync(ctx, src))
+	words := loader.GetWords()
+	assert.Contains(t, words, "赌场")
+	assert.Contains(t, words, "老千")
+
+	// 新版本替换旧快照，但不影响其他来源的词条
+	lexicon.set("赌场|gambling\n下注|gambling\n", `"v2"`, 0)
+	assert.NoError(t, loader.Sync(ctx, src))
+	words = loader.GetWords()
+	assert.Contains(t, words, "下注")
+	assert.NotContains(t, words, "老千")
+	assert.Contains(t, words, "本地词")
+
+	// 来源不可用时保留最后一份成功的快照
+	lexicon.set("", `"v3"`, 10)
+	assert.Error(t, loader.Sync(ctx, src))
+	words = loader.GetWords()
+	assert.Contains(t, words, "赌场")
+	assert.Contains(t, words, "下注")
+
+	// 内容格式错误时同样保留
+	lexicon.set("坏词|unknown|severity=1\n", `"v4"`, 0)
+	assert.Error(t, loader.Sync(ctx, src))
+	assert.Contains(t, loader.GetWords(), "下注")
+}
+
+// TestLoaderSyncMultiCategory 测试来源中同一词条列在多个分类下时保留全部分类
+func TestLoaderSyncMultiCategory(t *testing.T) {
+	lexicon := &lexiconServer{}
+	lexicon.set("骗子|gambling|severity=2\n骗子|scam|severity=2\n", `"v1"`, 0)
+	server := httptest.NewServer(lexicon)
+	defer server.Close()
+
+	src := NewHTTPSource(server.URL, WithRetry(1, 0))
+	snapshot, err := src.Fetch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, category.Gambling, snapshot.Words["骗子"])
+	assert.Equal(t, []category.Category{category.Gambling, category.Scam}, snapshot.Categories["骗子"])
+
+	loader := NewLoader()
+	assert.NoError(t, loader.Sync(context.Background(), NewHTTPSource(server.URL, WithRetry(1, 0))))
+	assert.Equal(t, []category.Category{category.Gambling, category.Scam}, loader.GetWordCategories("骗子"))
+	meta, _ := loader.GetWordMeta("骗子")
+	assert.Equal(t, 2, meta.Severity)
+}
+
+// TestLoaderSyncApplyFailure 测试快照应用失败后下次同步仍拉取完整内容
+func TestLoaderSyncApplyFailure(t *testing.T) {
+	lexicon := &lexiconServer{}
+	lexicon.set("大模型|ai\n", `"v1"`, 0)
+	server := httptest.NewServer(lexicon)
+	defer server.Close()
+
+	reg := category.NewRegistry()
+	if _, err := reg.Register("ai"); err != nil {
+		t.Fatal(err)
+	}
+	loader := NewLoader()
+	src := NewHTTPSource(server.URL, WithRegistry(reg), WithRetry(1, 0))
+	ctx := context.Background()
+
+	// 加载器的注册表中没有该分类，应用失败
+	assert.Error(t, loader.Sync(ctx, src))
+	assert.Equal(t, "", src.Version())
+
+	loader.SetRegistry(reg)
+	assert.NoError(t, loader.Sync(ctx, src))
+	assert.Contains(t, loader.GetWords(), "大模型")
+	assert.Equal(t, `"v1"`, src.Version())
+}
+
+// TestLoaderPoll 测试轮询同步
+func TestLoaderPoll(t *testing.T) {
+	lexicon := &lexiconServer{}
+	lexicon.set("赌场|gambling\n", `"v1"`, 0)
+	server := httptest.NewServer(lexicon)
+	defer server.Close()
+
+	loader := NewLoader()
+	src := NewHTTPSource(server.URL, WithRetry(1, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errs := make(chan error, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- loader.Poll(ctx, src, time.Millisecond*10, func(err error) { errs <- err })
+	}()
+
+	assert.Eventually(t, func() bool {
+		_, ok := loader.GetWords()["赌场"]
+		return ok
+	}, time.Second*5, time.Millisecond*10)
+
+	lexicon.set("", `"v2"`, 1)
+	select {
+	case err := <-errs:
+		assert.False(t, errors.Is(err, core.ErrNotModified))
+	case <-time.After(time.Second * 5):
+		t.Fatal("等待错误回调超时")
+	}
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Contains(t, loader.GetWords(), "赌场")
+}
+
+// TestLoaderPollInvalidInterval 测试非法同步间隔
+func TestLoaderPollInvalidInterval(t *testing.T) {
+	loader := NewLoader()
+	src := NewHTTPSource("http://127.0.0.1:0")
+	for _, interval := range []time.Duration{0, -time.Second} {
+		err := loader.Poll(context.Background(), src, interval, nil)
+		assert.ErrorIs(t, err, ErrInvalidInterval)
+	}
+}
@@ -0,0 +1,267 @@
+package dictionary
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// DefaultActor 未指定操作者时记录的操作者
+const DefaultActor = "system"
+
+// DefaultSource 内置词库的来源名称
+const DefaultSource = "default"
+
+// defaultHistoryLimit 默认保留的版本数
+const defaultHistoryLimit = 100
+
+// ErrVersionNotFound 指定的词库版本不存在或已超出保留范围
+var ErrVersionNotFound = errors.New("dictionary version not found")
+
+// ChangeOp 变更类型
+type ChangeOp string
+
+const (
+	OpAdd    ChangeOp = "add"    // 添加或修改词条
+	OpRemove ChangeOp = "remove" // 删除词条
+)
+
+// Change 词库变更记录
+type Change struct {
+	Version    uint64              // 所属版本
+	Op         ChangeOp            // 变更类型
+	Word       string              // 词条
+	Category   category.Category   // 变更后的主分类，删除时为删除前的主分类
+	Categories []category.Category // 属于多个分类时的全部分类
+	Meta       core.WordMeta       // 变更后的元数据
+	Actor      string              // 操作者
+	Time       time.Time           // 变更时间
+}
+
+// WordState 词条在某个版本中的状态
+type WordState struct {
+	Category   category.Category
+	Categories []category.Category // 属于多个分类时的全部分类
+	Meta       core.WordMeta
+}
+
+// Diff 两个版本之间的差异
+type Diff struct {
+	From    uint64
+	To      uint64
+	Added   map[string]WordState // To 中新增的词条
+	Removed map[string]WordState // To 中被删除的词条（值为 From 中的状态）
+	Changed map[string]WordState // 分类或元数据变化的词条（值为 To 中的状态）
+}
+
+// history 词库版本历史
+//
+// 保留最近 limit 个版本的变更，更早的变更折叠进 base 快照。
+type history struct {
+	version     uint64
+	baseVersion uint64
+	base        map[string]WordState
+	changes     []Change
+	pending     []Change
+	limit       int
+}
+
+// newHistory 创建版本历史
+func newHistory(limit int) history {
+	return history{
+		base:  make(map[string]WordState),
+		limit: limit,
+	}
+}
+
+// record 记录一条尚未提交的变更
+func (h *history) record(change Change) {
+	h.pending = append(h.pending, change)
+}
+
+// commit 将未提交的变更作为一个新版本提交，没有变更时不产生新版本
+func (h *history) commit(actor string) {
+	if len(h.pending) == 0 {
+		return
+	}
+	h.version++
+	now := time.Now()
+	for i := range h.pending {
+		h.pending[i].Version = h.version
+		h.pending[i].Actor = actor
+		h.pending[i].Time = now
+	}
+	h.changes = append(h.changes, h.pending...)
+	h.pending = nil
+	h.compact()
+}
+
+// compact 将超出保留范围的版本折叠进 base 快照
+func (h *history) compact() {
+	if h.limit <= 0 || h.version-h.baseVersion <= uint64(h.limit) {
+		return
+	}
+	cutoff := h.version - uint64(h.limit)
+	i := 0
+	for ; i < len(h.changes) && h.changes[i].Version <= cutoff; i++ {
+		apply(h.base, h.changes[i])
+	}
+	h.changes = append([]Change(nil), h.changes[i:]...)
+	h.baseVersion = cutoff
+}
+
+// stateAt 重建指定版本的词库
+func (h *history) stateAt(version uint64) (map[string]WordState, error) {
+	if version < h.baseVersion || version > h.version {
+		return nil, fmt.Errorf("%w: %d (available %d-%d)", ErrVersionNotFound, version, h.baseVersion, h.version)
+	}
+	state := make(map[string]WordState, len(h.base))
+	for word, ws := range h.base {
+		state[word] = ws
+	}
+	for _, change := range h.changes {
+		if change.Version > version {
+			break
+		}
+		apply(state, change)
+	}
+	return state, nil
+}
+
+// apply 将变更应用到词库状态
+func apply(state map[string]WordState, change Change) {
+	switch change.Op {
+	case OpAdd:
+		state[change.Word] = WordState{Category: change.Category, Categories: change.Categories, Meta: change.Meta}
+	case OpRemove:
+		delete(state, change.Word)
+	}
+}
+
+// commit 提交当前写操作产生的变更，调用方需持有 writeMu
+func (l *Loader) commit(actor string) {
+	if actor == "" {
+		actor = DefaultActor
+	}
+	l.history.commit(actor)
+}
+
+// Version 返回当前词库版本，每次产生变更的写操作都会使版本加一
+func (l *Loader) Version() uint64 {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	return l.history.version
+}
+
+// SetHistoryLimit 设置保留的版本数，小于等于 0 表示不限制
+func (l *Loader) SetHistoryLimit(limit int) {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	l.history.limit = limit
+	l.history.compact()
+}
+
+// Changelog 返回指定版本之后（不含）的所有变更记录
+func (l *Loader) Changelog(since uint64) []Change {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+
+	var changes []Change
+	for _, change := range l.history.changes {
+		if change.Version > since {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// Diff 比较两个版本的词库
+func (l *Loader) Diff(from, to uint64) (Diff, error) {
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+
+	fromState, err := l.history.stateAt(from)
+	if err != nil {
+		return Diff{}, err
+	}
+	toState, err := l.history.stateAt(to)
+	if err != nil {
+		return Diff{}, err
+	}
+	return diffStates(from, to, fromState, toState), nil
+}
+
+// diffStates 比较两份词库状态
+func diffStates(from, to uint64, fromState, toState map[string]WordState) Diff {
+	diff := Diff{
+		From:    from,
+		To:      to,
+		Added:   make(map[string]WordState),
+		Removed: make(map[string]WordState),
+		Changed: make(map[string]WordState),
+	}
+	for word, ws := range toState {
+		old, ok := fromState[word]
+		switch {
+		case !ok:
+			diff.Added[word] = ws
+		case !reflect.DeepEqual(old, ws):
+			diff.Changed[word] = ws
+		}
+	}
+	for word, ws := range fromState {
+		if _, ok := toState[word]; !ok {
+			diff.Removed[word] = ws
+		}
+	}
+	return diff
+}
+
+// Rollback 将词库恢复到指定版本，恢复本身作为一个新版本记录
+func (l *Loader) Rollback(version uint64) error {
+	return l.RollbackAs(DefaultActor, version)
+}
+
+// RollbackAs 以指定操作者身份将词库恢复到指定版本
+func (l *Loader) RollbackAs(actor string, version uint64) error {
+	return l.write(actor, true, func() error {
+		return l.rollback(actor, version)
+	})
+}
+
+// rollback 将词库恢复到指定版本，调用方需持有 writeMu
+func (l *Loader) rollback(actor string, version uint64) error {
+	target, err := l.history.stateAt(version)
+	if err != nil {
+		return err
+	}
+	current, err := l.history.stateAt(l.history.version)
+	if err != nil {
+		return err
+	}
+
+	diff := diffStates(l.history.version, version, current, target)
+	for word := range diff.Removed {
+		l.removeWordInternal(word)
+	}
+	for _, changed := range []map[string]WordState{diff.Added, diff.Changed} {
+		for word, ws := range changed {
+			// 恢复后的分类统一记为操作者的贡献
+			l.track(word, func() {
+				set := category.NewSet(ws.Categories...)
+				set.Add(ws.Category)
+				l.provenance[word] = map[string]*category.Set{actor: set}
+				if ws.Meta.IsZero() {
+					l.meta.Delete(word)
+				} else {
+					l.meta.Store(word, ws.Meta)
+				}
+			})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package dictionary
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// versionObserver 记录最近一次通知的版本
+type versionObserver struct {
+	mu      sync.Mutex
+	version uint64
+	words   map[string]category.Category
+}
+
+func (o *versionObserver) OnWordsChanged(words map[string]category.Category) {}
+
+func (o *versionObserver) OnVersionChanged(version uint64, words map[string]category.Category) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.version = version
+	o.words = words
+}
+
+// TestLoaderVersion 测试版本号与变更记录
+func TestLoaderVersion(t *testing.T) {
+	loader := NewLoader()
+	observer := &versionObserver{}
+	loader.AddObserver(observer)
+	assert.Equal(t, uint64(0), loader.Version())
+
+	assert.NoError(t, loader.AddWord("赌场", category.Gambling))
+	assert.Equal(t, uint64(1), loader.Version())
+
+	// 内容未变化时不产生新版本
+	assert.NoError(t, loader.AddWord("赌场", category.Gambling))
+	assert.Equal(t, uint64(1), loader.Version())
+
+	assert.NoError(t, loader.AddWordsAs("alice", map[string]category.Category{
+		"骗子": category.Scam,
+		"老千": category.Gambling,
+	}))
+	assert.NoError(t, loader.RemoveWordsAs("bob", []string{"赌场"}))
+	assert.Equal(t, uint64(3), loader.Version())
+
+	observer.mu.Lock()
+	assert.Equal(t, uint64(3), observer.version)
+	assert.NotContains(t, observer.words, "赌场")
+	observer.mu.Unlock()
+
+	changes := loader.Changelog(1)
+	assert.Len(t, changes, 3)
+	for _, change := range changes[:2] {
+		assert.Equal(t, uint64(2), change.Version)
+		assert.Equal(t, OpAdd, change.Op)
+		assert.Equal(t, "alice", change.Actor)
+	}
+	assert.Equal(t, Change{Version: 3, Op: OpRemove, Word: "赌场", Category: category.Gambling, Actor: "bob", Time: changes[2].Time}, changes[2])
+	assert.False(t, changes[2].Time.IsZero())
+}
+
+// TestLoaderDiffAndRollback 测试版本比较与回滚
+func TestLoaderDiffAndRollback(t *testing.T) {
+	loader := NewLoader()
+	assert.NoError(t, loader.AddWord("赌场", category.Gambling))
+	assert.NoError(t, loader.AddWord("骗子", category.Scam))
+	good := loader.Version()
+
+	// 一次错误的批量导入
+	assert.NoError(t, loader.AddWords(map[string]category.Category{
+		"正常词": category.Political,
+		"骗子":  category.Gambling,
+	}))
+	assert.NoError(t, loader.RemoveWord("赌场"))
+
+	diff, err := loader.Diff(good, loader.Version())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]WordState{"正常词": {Category: category.Political}}, diff.Added)
+	assert.Equal(t, map[string]WordState{"赌场": {Category: category.Gambling}}, diff.Removed)
+	assert.Equal(t, map[string]WordState{"骗子": {Category: category.Gambling}}, diff.Changed)
+
+	bad := loader.Version()
+	assert.NoError(t, loader.RollbackAs("admin", good))
+	assert.Equal(t, bad+1, loader.Version())
+	assert.Equal(t, map[string]category.Category{
+		"赌场": category.Gambling,
+		"骗子": category.Scam,
+	}, loader.GetWords())
+
+	for _, change := range loader.Changelog(bad) {
+		assert.Equal(t, "admin", change.Actor)
+	}
+
+	// 回滚后的词库与目标版本一致
+	diff, err = loader.Diff(good, loader.Version())
+	assert.NoError(t, err)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Removed)
+	assert.Empty(t, diff.Changed)
+}
+
+// TestLoaderHistoryLimit 测试历史版本保留范围
+func TestLoaderHistoryLimit(t *testing.T) {
+	loader := NewLoader()
+	loader.SetHistoryLimit(2)
+	for _, word := range []string{"一", "二", "三", "四"} {
+		assert.NoError(t, loader.AddWord(word, category.Custom))
+	}
+
+	assert.ErrorIs(t, loader.Rollback(1), ErrVersionNotFound)
+	_, err := loader.Diff(0, 4)
+	assert.ErrorIs(t, err, ErrVersionNotFound)
+	_, err = loader.Diff(2, 5)
+	assert.ErrorIs(t, err, ErrVersionNotFound)
+
+	assert.NoError(t, loader.Rollback(2))
+	assert.Equal(t, map[string]category.Category{
+		"一": category.Custom,
+		"二": category.Custom,
+	}, loader.GetWords())
+}
+
+// reentrantObserver 在回调中读取加载器状态
+type reentrantObserver struct {
+	loader   *Loader
+	versions []uint64
+}
+
+func (o *reentrantObserver) OnWordsChanged(words map[string]category.Category) {
+	o.versions = append(o.versions, o.loader.Version())
+	_ = o.loader.GetWordCategories("赌场")
+	_ = o.loader.Provenance("赌场")
+	_ = o.loader.Changelog(0)
+	_ = o.loader.Export(io.Discard, core.DictFormatText)
+}
+
+// TestLoaderObserverReentrant 测试观察者回调中可以调用加载器的方法
+func TestLoaderObserverReentrant(t *testing.T) {
+	loader := NewLoader()
+	observer := &reentrantObserver{loader: loader}
+	loader.AddObserver(observer)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, loader.AddWords(map[string]category.Category{"赌场": category.Gambling}))
+		assert.NoError(t, loader.Rollback(0))
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("observer calling loader methods deadlocked")
+	}
+	assert.Equal(t, []uint64{1, 2}, observer.versions)
+}
+
+// TestLoaderWriteFailure 测试写操作失败时撤销已做的修改且不通知观察者
+func TestLoaderWriteFailure(t *testing.T) {
+	loader := NewLoader()
+	assert.NoError(t, loader.AddWords(map[string]category.Category{"赌场": category.Gambling}))
+
+	observer := &countingObserver{}
+	loader.AddObserver(observer)
+
+	errFail := errors.New("write failed")
+	err := loader.write("alice", true, func() error {
+		assert.NoError(t, loader.addWordInternal("alice", "骗子", category.Scam))
+		assert.NoError(t, loader.addEntryInternal("alice", entry{word: "赌场", cat: category.Scam, meta: core.WordMeta{Severity: 3}}))
+		loader.removeWordInternal("赌场")
+		return errFail
+	})
+	assert.ErrorIs(t, err, errFail)
+
+	assert.Equal(t, uint64(1), loader.Version())
+	assert.Empty(t, loader.Changelog(1))
+	assert.Equal(t, map[string]category.Category{"赌场": category.Gambling}, loader.GetWords())
+	assert.Equal(t, map[string][]category.Category{DefaultActor: {category.Gambling}}, loader.Provenance("赌场"))
+	_, hasMeta := loader.GetWordMeta("赌场")
+	assert.False(t, hasMeta)
+	count, _ := observer.snapshot()
+	assert.Zero(t, count)
+
+	// 失败的变更不会混入下一个版本
+	assert.NoError(t, loader.AddWord("下注", category.Gambling))
+	assert.Equal(t, uint64(2), loader.Version())
+	changes := loader.Changelog(1)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "下注", changes[0].Word)
+}
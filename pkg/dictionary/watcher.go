@@ -0,0 +1,237 @@
+package dictionary
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Watcher 通过轮询监听词库文件变更并热加载
+//
+// 文件变更后需保持稳定 debounce 时长才会重新加载；新内容校验通过后，
+// 以一次变更替换该文件此前贡献的词条并通知观察者。文件格式错误时保留旧词库，
+// 并通过错误回调上报。
+type Watcher struct {
+	loader   *Loader
+	paths    []string
+	interval time.Duration
+	debounce time.Duration
+	onError  func(path string, err error)
+	onReload func(path string, words int)
+
+	mu    sync.Mutex
+	files map[string]*watchedFile
+}
+
+// watchedFile 单个被监听文件的状态
+type watchedFile struct {
+	modTime   time.Time
+	size      int64
+	changedAt time.Time           // 最近一次观察到变化的时间
+	pending   bool                // 是否有尚未加载的变化
+	words     map[string]struct{} // 该文件当前贡献的词条
+}
+
+// WatcherOption 热加载配置项
+type WatcherOption func(*Watcher)
+
+// WithInterval 设置轮询间隔
+func WithInterval(interval time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		if interval > 0 {
+			w.interval = interval
+		}
+	}
+}
+
+// WithDebounce 设置防抖时长
+func WithDebounce(debounce time.Duration) WatcherOption {
+	return func(w *Watcher) {
+		if debounce >= 0 {
+			w.debounce = debounce
+		}
+	}
+}
+
+// WithErrorHandler 设置加载失败回调
+func WithErrorHandler(fn func(path string, err error)) WatcherOption {
+	return func(w *Watcher) {
+		w.onError = fn
+	}
+}
+
+// WithReloadHandler 设置加载成功回调
+func WithReloadHandler(fn func(path string, words int)) WatcherOption {
+	return func(w *Watcher) {
+		w.onReload = fn
+	}
+}
+
+// NewWatcher 创建词库文件热加载器
+func NewWatcher(loader *Loader, paths []string, opts ...WatcherOption) *Watcher {
+	w := &Watcher{
+		loader:   loader,
+		paths:    append([]string(nil), paths...),
+		interval: time.Second,
+		debounce: time.Millisecond * 500,
+		files:    make(map[string]*watchedFile, len(paths)),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Load 立即加载所有文件，返回遇到的第一个错误，出错的文件不影响其他文件
+func (w *Watcher) Load(ctx context.Context) error {
+	var firstErr error
+	for _, path := range w.paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		info, err := os.Stat(path)
+		if err == nil {
+			err = w.reload(ctx, path, info)
+		}
+		if err != nil {
+			w.reportError(path, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// Run 加载所有文件并持续轮询，直到 ctx 结束
+func (w *Watcher) Run(ctx context.Context) error {
+	_ = w.Load(ctx)
+	return w.Watch(ctx)
+}
+
+// Watch 持续轮询文件变更直到 ctx 结束，不做初次加载，用于已调用 Load 的场景
+func (w *Watcher) Watch(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			w.poll(ctx)
+		}
+	}
+}
+
+// poll 检查一次所有文件
+func (w *Watcher) poll(ctx context.Context) {
+	now := time.Now()
+	for _, path := range w.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			w.reportError(path, err)
+			continue
+		}
+
+		w.mu.Lock()
+		state, ok := w.files[path]
+		if !ok {
+			state = &watchedFile{}
+			w.files[path] = state
+		}
+		if !info.ModTime().Equal(state.modTime) || info.Size() != state.size {
+			state.modTime = info.ModTime()
+			state.size = info.Size()
+			state.changedAt = now
+			state.pending = true
+		}
+		ready := state.pending && now.Sub(state.changedAt) >= w.debounce
+		w.mu.Unlock()
+
+		if ready {
+			if err := w.reload(ctx, path, info); err != nil {
+				w.reportError(path, err)
+			}
+		}
+	}
+}
+
+// reload 校验并加载单个文件，回调在释放锁之后执行，可在回调中再次调用 Load
+func (w *Watcher) reload(ctx context.Context, path string, info os.FileInfo) error {
+	count, err := w.apply(ctx, path, info)
+	if err != nil {
+		return err
+	}
+
+	w.loader.log().Info("dictionary file reloaded", "path", path, "words", count)
+	if w.onReload != nil {
+		w.onReload(path, count)
+	}
+	return nil
+}
+
+// apply 解析文件并替换其贡献的词条，返回文件中的词条数
+func (w *Watcher) apply(ctx context.Context, path string, info os.FileInfo) (int, error) {
+	entries, err := w.decodeFile(ctx, path)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state, ok := w.files[path]
+	if !ok {
+		state = &watchedFile{}
+		w.files[path] = state
+	}
+	// 无论成功与否都记录当前版本，避免对同一份错误内容反复报错
+	state.modTime = info.ModTime()
+	state.size = info.Size()
+	state.pending = false
+	if err != nil {
+		return 0, err
+	}
+
+	words := make(map[string]struct{}, len(entries))
+	for _, e := range entries {
+		words[e.word] = struct{}{}
+	}
+	var remove []string
+	for word := range state.words {
+		if _, ok := words[word]; !ok {
+			remove = append(remove, word)
+		}
+	}
+
+	if err := w.loader.applyChanges("watcher:"+path, "watcher:"+path, remove, entries); err != nil {
+		return 0, fmt.Errorf("failed to apply %s: %w", filepath.Base(path), err)
+	}
+	state.words = words
+	return len(words), nil
+}
+
+// decodeFile 读取并解析文件
+func (w *Watcher) decodeFile(ctx context.Context, path string) ([]entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reg := w.loader.Registry()
+	entries, err := decodeEntries(ctx, f, formatFromPath(path), reg, categoryFromPath(reg, path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", filepath.Base(path), err)
+	}
+	return entries, nil
+}
+
+// reportError 记录日志并上报错误
+func (w *Watcher) reportError(path string, err error) {
+	w.loader.log().Warn("failed to reload dictionary file", "path", path, "error", err)
+	if w.onError != nil {
+		w.onError(path, err)
+	}
+}
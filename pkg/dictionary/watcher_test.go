@@ -0,0 +1,264 @@
+package dictionary
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// countingObserver 记录通知次数与最近一次词库
+type countingObserver struct {
+	mu    sync.Mutex
+	count int
+	words map[string]category.Category
+}
+
+func (o *countingObserver) OnWordsChanged(words map[string]category.Category) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.count++
+	o.words = words
+}
+
+func (o *countingObserver) snapshot() (int, map[string]category.Category) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.count, o.words
+}
+
+// writeFile 写入文件并设置修改时间，避免文件系统时间精度导致变更未被发现
+func writeFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	assert.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// TestWatcherLoad 测试初次加载
+func TestWatcherLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gambling.txt")
+	writeFile(t, path, "赌场\n骗子|scam\n", time.Now())
+
+	loader := NewLoader()
+	observer := &countingObserver{}
+	loader.AddObserver(observer)
+
+	w := NewWatcher(loader, []string{path})
+	assert.NoError(t, w.Load(context.Background()))
+
+	count, words := observer.snapshot()
+	assert.Equal(t, 1, count)
+	assert.Equal(t, category.Gambling, words["赌场"])
+	assert.Equal(t, category.Scam, words["骗子"])
+}
+
+// TestWatcherReload 测试文件变更、错误内容与防抖
+func TestWatcherReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gambling.txt")
+	base := time.Now().Add(-time.Hour)
+	writeFile(t, path, "赌场\n老千\n", base)
+
+	loader := NewLoader()
+	_ = loader.AddWord("其他词", category.Political)
+
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	w := NewWatcher(loader, []string{path},
+		WithDebounce(time.Hour),
+		WithErrorHandler(func(_ string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		}),
+	)
+	ctx := context.Background()
+	assert.NoError(t, w.Load(ctx))
+
+	// 变更后防抖期内不加载
+	writeFile(t, path, "赌场\n下注\n", base.Add(time.Minute))
+	w.poll(ctx)
+	_, exists := loader.GetWords()["下注"]
+	assert.False(t, exists)
+
+	// 防抖结束后加载，并移除该文件不再包含的词
+	w.debounce = 0
+	w.poll(ctx)
+	words := loader.GetWords()
+	assert.Contains(t, words, "下注")
+	assert.Contains(t, words, "赌场")
+	assert.NotContains(t, words, "老千")
+	assert.Contains(t, words, "其他词")
+
+	// 格式错误时保留旧词库并上报错误
+	writeFile(t, path, "赌场\n坏词|unknown|severity=1\n", base.Add(2*time.Minute))
+	w.poll(ctx)
+	words = loader.GetWords()
+	assert.Contains(t, words, "下注")
+	assert.NotContains(t, words, "坏词")
+
+	mu.Lock()
+	assert.Len(t, errs, 1)
+	mu.Unlock()
+
+	// 同一份错误内容不重复上报
+	w.poll(ctx)
+	mu.Lock()
+	assert.Len(t, errs, 1)
+	mu.Unlock()
+}
+
+// TestWatcherReloadHandler 测试加载回调中可再次调用 Load
+func TestWatcherReloadHandler(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gambling.txt")
+	writeFile(t, path, "赌场\n", time.Now())
+
+	var (
+		w     *Watcher
+		calls int
+	)
+	w = NewWatcher(NewLoader(), []string{path}, WithReloadHandler(func(string, int) {
+		calls++
+		if calls == 1 {
+			assert.NoError(t, w.Load(context.Background()))
+		}
+	}))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		assert.NoError(t, w.Load(context.Background()))
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("reload handler calling Load deadlocked")
+	}
+	assert.Equal(t, 2, calls)
+}
+
+// TestWatcherRun 测试轮询运行与取消
+func TestWatcherRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scam.txt")
+	writeFile(t, path, "骗子\n", time.Now().Add(-time.Hour))
+
+	loader := NewLoader()
+	reloaded := make(chan int, 10)
+	w := NewWatcher(loader, []string{path},
+		WithInterval(time.Millisecond*10),
+		WithDebounce(0),
+		WithReloadHandler(func(_ string, words int) { reloaded <- words }),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	assert.Equal(t, 1, <-reloaded)
+	writeFile(t, path, "骗子\n诈骗犯\n", time.Now())
+	select {
+	case n := <-reloaded:
+		assert.Equal(t, 2, n)
+	case <-time.After(time.Second * 5):
+		t.Fatal("等待热加载超时")
+	}
+	assert.Equal(t, category.Scam, loader.GetWords()["诈骗犯"])
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
+
+// categoriesObserver 记录最近一次多分类词条
+type categoriesObserver struct {
+	countingObserver
+	categories map[string][]category.Category
+}
+
+func (o *categoriesObserver) OnCategoriesChanged(categories map[string][]category.Category) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.categories = categories
+}
+
+// TestWatcherMultiCategory 测试多个来源的分类合并与来源追踪
+func TestWatcherMultiCategory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gambling.txt")
+	base := time.Now().Add(-time.Hour)
+	writeFile(t, path, "骗子\n赌场\n", base)
+
+	loader := NewLoader()
+	assert.NoError(t, loader.AddWord("骗子", category.Scam))
+	observer := &categoriesObserver{}
+	loader.AddObserver(observer)
+
+	w := NewWatcher(loader, []string{path}, WithDebounce(0))
+	ctx := context.Background()
+	assert.NoError(t, w.Load(ctx))
+
+	// 不同来源的分类合并，主分类为序号最小的分类
+	assert.Equal(t, category.Gambling, loader.GetWords()["骗子"])
+	assert.Equal(t, []category.Category{category.Gambling, category.Scam}, loader.GetWordCategories("骗子"))
+	assert.Equal(t, map[string][]category.Category{
+		DefaultActor:      {category.Scam},
+		"watcher:" + path: {category.Gambling},
+	}, loader.Provenance("骗子"))
+	observer.mu.Lock()
+	assert.Equal(t, map[string][]category.Category{"骗子": {category.Gambling, category.Scam}}, observer.categories)
+	observer.mu.Unlock()
+
+	changes := loader.Changelog(loader.Version() - 1)
+	for _, change := range changes {
+		if change.Word == "骗子" {
+			assert.Equal(t, []category.Category{category.Gambling, category.Scam}, change.Categories)
+		}
+	}
+
+	// 文件不再包含该词时只撤销文件贡献的分类
+	writeFile(t, path, "赌场\n", base.Add(time.Minute))
+	w.poll(ctx)
+	assert.Equal(t, category.Scam, loader.GetWords()["骗子"])
+	assert.Equal(t, []category.Category{category.Scam}, loader.GetWordCategories("骗子"))
+
+	// 同一来源再次添加时替换其分类
+	assert.NoError(t, loader.AddWord("赌场", category.Political))
+	assert.Equal(t, []category.Category{category.Political, category.Gambling}, loader.GetWordCategories("赌场"))
+	assert.NoError(t, loader.AddWord("骗子", category.Political))
+	assert.Equal(t, []category.Category{category.Political}, loader.GetWordCategories("骗子"))
+
+	// 删除词条时撤销所有来源
+	assert.NoError(t, loader.RemoveWord("赌场"))
+	assert.Nil(t, loader.GetWordCategories("赌场"))
+	assert.Nil(t, loader.Provenance("赌场"))
+}
+
+// TestWatcherLogging 测试加载结果通过加载器的日志记录器输出
+func TestWatcherLogging(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gambling.txt")
+	writeFile(t, path, "赌场\n老千\n", time.Now())
+
+	var buf bytes.Buffer
+	loader := NewLoader()
+	loader.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	w := NewWatcher(loader, []string{path, filepath.Join(dir, "missing.txt")})
+	assert.Error(t, w.Load(context.Background()))
+
+	logs := buf.String()
+	assert.Contains(t, logs, `level=INFO msg="dictionary file reloaded" path=`+path+" words=2")
+	assert.Contains(t, logs, `level=DEBUG msg="dictionary changed"`)
+	assert.Contains(t, logs, `level=WARN msg="failed to reload dictionary file" path=`+filepath.Join(dir, "missing.txt"))
+}
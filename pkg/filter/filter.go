@@ -2,6 +2,9 @@ package filter
 
 import (
 	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/ttofTnT/go-swd/pkg/core"
 	"github.com/ttofTnT/go-swd/pkg/types/category"
@@ -10,6 +13,7 @@ import (
 // filter 实现了敏感词过滤器接口
 type filter struct {
 	detector core.Detector
+	inst     atomic.Pointer[core.Instrumentation] // 指标采集，未设置时为 nil
 }
 
 // NewFilter 创建一个新的过滤器实例
@@ -19,22 +23,36 @@ func NewFilter(detector core.Detector) core.Filter {
 	}
 }
 
+// SetInstrumentation 实现 Instrumented 接口，设置替换的指标采集
+func (f *filter) SetInstrumentation(inst core.Instrumentation) {
+	if inst == nil {
+		f.inst.Store(nil)
+		return
+	}
+	f.inst.Store(&inst)
+}
+
+// observeReplace 向指标采集报告一次替换，所有替换方法最终只会经过一次
+func (f *filter) observeReplace(start time.Time, replaced *int) {
+	if p := f.inst.Load(); p != nil {
+		(*p).ObserveReplace(*replaced, time.Since(start))
+	}
+}
+
 // Replace 使用指定的替换字符替换敏感词
 func (f *filter) Replace(text string, replacement rune) string {
 	if text == "" {
 		return text
 	}
 	return f.ReplaceWithStrategy(text, func(word core.SensitiveWord) string {
-		chars := make([]rune, len([]rune(word.Word)))
-		for i := range chars {
-			chars[i] = replacement
-		}
-		return string(chars)
+		return maskOf(word, replacement)
 	})
 }
 
 // ReplaceIn 使用指定的替换字符替换指定分类的敏感词
 func (f *filter) ReplaceIn(text string, replacement rune, categories ...category.Category) string {
+	replaced := 0
+	defer f.observeReplace(time.Now(), &replaced)
 	if text == "" || len(categories) == 0 {
 		return text
 	}
@@ -58,10 +76,29 @@ func (f *filter) ReplaceIn(text string, replacement rune, categories ...category
 			runes[i] = replacement
 		}
 	}
+	replaced = len(matches)
 
 	return string(runes)
 }
 
+// ReplaceNotIn 使用指定的替换字符替换指定分类以外的敏感词
+func (f *filter) ReplaceNotIn(text string, replacement rune, categories ...category.Category) string {
+	return f.ReplaceWithStrategyNotIn(text, func(word core.SensitiveWord) string {
+		return maskOf(word, replacement)
+	}, categories...)
+}
+
+// maskOf 返回与敏感词在原文中所占字符数相同的替换字符串
+//
+// 预处理可能增删字符（如连字展开、去除零宽字符），原文区间的长度不一定等于词条长度。
+func maskOf(word core.SensitiveWord, replacement rune) string {
+	n := word.EndPos - word.StartPos
+	if n <= 0 {
+		n = len([]rune(word.Word))
+	}
+	return strings.Repeat(string(replacement), n)
+}
+
 // ReplaceWithAsterisk 使用 * 号替换敏感词
 func (f *filter) ReplaceWithAsterisk(text string) string {
 	return f.Replace(text, '*')
@@ -72,72 +109,78 @@ func (f *filter) ReplaceWithAsteriskIn(text string, categories ...category.Categ
 	return f.ReplaceIn(text, '*', categories...)
 }
 
+// ReplaceWithAsteriskNotIn 使用 * 号替换指定分类以外的敏感词
+func (f *filter) ReplaceWithAsteriskNotIn(text string, categories ...category.Category) string {
+	return f.ReplaceNotIn(text, '*', categories...)
+}
+
 // ReplaceWithStrategy 使用自定义替换策略替换敏感词
 func (f *filter) ReplaceWithStrategy(text string, strategy func(word core.SensitiveWord) string) string {
+	replaced := 0
+	defer f.observeReplace(time.Now(), &replaced)
 	if text == "" || strategy == nil {
 		return text
 	}
 	matches := f.detector.MatchAll(text)
-	return f.replaceWords(text, matches, strategy)
+	result, replaced := f.replaceWords(text, matches, strategy)
+	return result
 }
 
 // ReplaceWithStrategyIn 使用自定义策略替换指定分类的敏感词
 func (f *filter) ReplaceWithStrategyIn(text string, strategy func(word core.SensitiveWord) string, categories ...category.Category) string {
+	replaced := 0
+	defer f.observeReplace(time.Now(), &replaced)
 	if text == "" || len(categories) == 0 || strategy == nil {
 		return text
 	}
 
-	// 获取所有匹配的敏感词
 	matches := f.detector.MatchAllIn(text, categories...)
-	if len(matches) == 0 {
-		return text
-	}
-
-	// 按照位置排序，从后向前替换
-	sort.Slice(matches, func(i, j int) bool {
-		return matches[i].StartPos > matches[j].StartPos
-	})
+	result, replaced := f.replaceWords(text, matches, strategy)
+	return result
+}
 
-	// 转换为rune数组以便处理中文
-	result := []rune(text)
-	for _, match := range matches {
-		// 获取替换文本
-		replacement := []rune(strategy(match))
-		// 计算需要替换的长度
-		replaceLen := match.EndPos - match.StartPos
-		if len(replacement) != replaceLen {
-			// 如果替换文本长度不同，需要调整结果数组
-			newResult := make([]rune, len(result)+(len(replacement)-replaceLen))
-			copy(newResult, result[:match.StartPos])
-			copy(newResult[match.StartPos:], replacement)
-			copy(newResult[match.StartPos+len(replacement):], result[match.EndPos:])
-			result = newResult
-		} else {
-			// 长度相同，直接替换
-			copy(result[match.StartPos:], replacement)
-		}
+// ReplaceWithStrategyNotIn 使用自定义策略替换指定分类以外的敏感词
+func (f *filter) ReplaceWithStrategyNotIn(text string, strategy func(word core.SensitiveWord) string, categories ...category.Category) string {
+	replaced := 0
+	defer f.observeReplace(time.Now(), &replaced)
+	if text == "" || strategy == nil {
+		return text
 	}
-
-	return string(result)
+	matches := f.detector.MatchAllNotIn(text, categories...)
+	result, replaced := f.replaceWords(text, matches, strategy)
+	return result
 }
 
-// replaceWords 替换文本中的敏感词
-func (f *filter) replaceWords(text string, matches []core.SensitiveWord, strategy func(word core.SensitiveWord) string) string {
+// replaceWords 替换文本中的敏感词，返回替换后的文本和被替换的敏感词数量
+func (f *filter) replaceWords(text string, matches []core.SensitiveWord, strategy func(word core.SensitiveWord) string) (string, int) {
 	if len(matches) == 0 {
-		return text
+		return text, 0
 	}
 
+	// 按起始位置排序，起始位置相同时较长的在前，重叠的匹配只替换最先出现的一个
+	matches = append([]core.SensitiveWord(nil), matches...)
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].StartPos != matches[j].StartPos {
+			return matches[i].StartPos < matches[j].StartPos
+		}
+		return matches[i].EndPos > matches[j].EndPos
+	})
+
 	runes := []rune(text)
 	result := make([]rune, 0, len(runes))
-	lastPos := 0
+	lastPos, replaced := 0, 0
 
 	for _, match := range matches {
+		if match.StartPos < lastPos {
+			continue
+		}
 		// 添加敏感词前的文本
 		result = append(result, runes[lastPos:match.StartPos]...)
 		// 添加替换后的文本
 		replacement := []rune(strategy(match))
 		result = append(result, replacement...)
 		lastPos = match.EndPos
+		replaced++
 	}
 
 	// 添加最后一个敏感词后的文本
@@ -145,5 +188,5 @@ func (f *filter) replaceWords(text string, matches []core.SensitiveWord, strateg
 		result = append(result, runes[lastPos:]...)
 	}
 
-	return string(result)
+	return string(result), replaced
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/detector"
 	"github.com/ttofTnT/go-swd/pkg/types/category"
 )
 
@@ -53,6 +54,38 @@ func (m *mockDetector) MatchAllIn(text string, categories ...category.Category)
 	return nil
 }
 
+func (m *mockDetector) DetectNotIn(text string, categories ...category.Category) bool {
+	return len(m.MatchAllNotIn(text, categories...)) > 0
+}
+
+func (m *mockDetector) MatchNotIn(text string, categories ...category.Category) *core.SensitiveWord {
+	matches := m.MatchAllNotIn(text, categories...)
+	if len(matches) > 0 {
+		return &matches[0]
+	}
+	return nil
+}
+
+func (m *mockDetector) MatchAllNotIn(text string, categories ...category.Category) []core.SensitiveWord {
+	var result []core.SensitiveWord
+	for _, match := range m.MatchAll(text) {
+		excluded := false
+		for _, cat := range categories {
+			if cat.Contains(match.Category) {
+				excluded = true
+			}
+		}
+		if !excluded {
+			result = append(result, match)
+		}
+	}
+	return result
+}
+
+func (m *mockDetector) Score(text string) core.RiskScore {
+	return core.RiskScore{Matches: m.MatchAll(text)}
+}
+
 func TestFilter_Replace(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -634,3 +667,117 @@ func TestFilter_ReplaceWithStrategyIn(t *testing.T) {
 		})
 	}
 }
+
+func TestFilter_ReplaceNotIn(t *testing.T) {
+	matches := []core.SensitiveWord{
+		{Word: "bad", StartPos: 0, EndPos: 3, Category: category.Violence},
+		{Word: "evil", StartPos: 10, EndPos: 14, Category: category.Profanity},
+	}
+	tests := []struct {
+		name       string
+		text       string
+		categories []category.Category
+		want       string
+	}{
+		{
+			name: "empty text",
+			text: "",
+			want: "",
+		},
+		{
+			name: "no exclusion",
+			text: "bad hello evil world",
+			want: "*** hello **** world",
+		},
+		{
+			name:       "exclude profanity",
+			text:       "bad hello evil world",
+			categories: []category.Category{category.Profanity},
+			want:       "*** hello evil world",
+		},
+		{
+			name:       "exclude all",
+			text:       "bad hello evil world",
+			categories: []category.Category{category.Profanity, category.Violence},
+			want:       "bad hello evil world",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := &mockDetector{
+				matchAllFunc: func(text string) []core.SensitiveWord {
+					return matches
+				},
+			}
+			f := NewFilter(detector)
+			if got := f.ReplaceWithAsteriskNotIn(tt.text, tt.categories...); got != tt.want {
+				t.Errorf("ReplaceWithAsteriskNotIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter_ReplaceOverlapping(t *testing.T) {
+	detector := &mockDetector{
+		matchAllFunc: func(text string) []core.SensitiveWord {
+			return []core.SensitiveWord{
+				{Word: "bad", StartPos: 4, EndPos: 7, Category: category.Violence},
+				{Word: "so bad", StartPos: 1, EndPos: 7, Category: category.Violence},
+			}
+		},
+	}
+	f := NewFilter(detector)
+	if got, want := f.ReplaceWithAsterisk("[so bad]"), "[******]"; got != want {
+		t.Errorf("ReplaceWithAsterisk() = %v, want %v", got, want)
+	}
+}
+
+// TestFilter_ReplacePreprocessed 测试预处理增删字符时按原文位置替换
+func TestFilter_ReplacePreprocessed(t *testing.T) {
+	options := core.SWDOptions{NormalizeUnicode: true, StripInvisible: true}
+	d, err := detector.NewDetectorWithWords(options, map[string]category.Category{
+		"testword": category.Gambling,
+		"fire":     category.Violence,
+	}, 0)
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+	f := NewFilter(d)
+	brackets := func(word core.SensitiveWord) string { return "[" + word.Word + "]" }
+
+	tests := []struct {
+		name string
+		text string
+		mask string // * 号替换的结果
+		tag  string // 自定义策略替换的结果
+	}{
+		{"连字展开", "ﬁﬁﬁﬁtestword", "ﬁﬁﬁﬁ********", "ﬁﬁﬁﬁ[testword]"},
+		{"词内连字", "ﬁre!", "***!", "[fire]!"},
+		{"零宽字符在词前", "a\u200btestword tail", "a\u200b******** tail", "a\u200b[testword] tail"},
+		{"零宽字符在词内", "test\u200bword tail", "********* tail", "[testword] tail"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			all := []category.Category{category.Gambling, category.Violence}
+			for name, got := range map[string]string{
+				"ReplaceWithAsterisk":      f.ReplaceWithAsterisk(tt.text),
+				"ReplaceWithAsteriskIn":    f.ReplaceWithAsteriskIn(tt.text, all...),
+				"ReplaceWithAsteriskNotIn": f.ReplaceWithAsteriskNotIn(tt.text, category.Custom),
+			} {
+				if got != tt.mask {
+					t.Errorf("%s(%q) = %q, want %q", name, tt.text, got, tt.mask)
+				}
+			}
+			for name, got := range map[string]string{
+				"ReplaceWithStrategy":      f.ReplaceWithStrategy(tt.text, brackets),
+				"ReplaceWithStrategyIn":    f.ReplaceWithStrategyIn(tt.text, brackets, all...),
+				"ReplaceWithStrategyNotIn": f.ReplaceWithStrategyNotIn(tt.text, brackets, category.Custom),
+			} {
+				if got != tt.tag {
+					t.Errorf("%s(%q) = %q, want %q", name, tt.text, got, tt.tag)
+				}
+			}
+		})
+	}
+}
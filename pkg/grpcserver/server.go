@@ -0,0 +1,273 @@
+// Package grpcserver 以 gRPC 提供敏感词检测服务
+//
+// 接口定义见 swdpb/swd.proto，修改后使用 protoc-gen-go 与 protoc-gen-go-grpc
+// （参数 paths=source_relative）重新生成 swdpb 中的代码。
+// 鉴权、限流等横切逻辑请通过 grpc.ServerOption 中的拦截器实现。
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/grpcserver/swdpb"
+	"github.com/ttofTnT/go-swd/pkg/swd"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+var (
+	// ErrNoEngine 没有提供检测引擎
+	ErrNoEngine = errors.New("no engine provided")
+	// errConflictingFilters 同时指定了 include 与 exclude
+	errConflictingFilters = errors.New("include and exclude are mutually exclusive")
+)
+
+// Server 基于 SWD 实现 swdpb.SensitiveWordServiceServer
+type Server struct {
+	swdpb.UnimplementedSensitiveWordServiceServer
+	engine *swd.SWD
+}
+
+// NewServer 创建 gRPC 检测服务
+func NewServer(engine *swd.SWD) (*Server, error) {
+	if engine == nil {
+		return nil, ErrNoEngine
+	}
+	return &Server{engine: engine}, nil
+}
+
+// Register 将服务注册到 gRPC 服务器
+func (s *Server) Register(g *grpc.Server) {
+	swdpb.RegisterSensitiveWordServiceServer(g, s)
+}
+
+// filter 解析后的分类过滤条件
+type filter struct {
+	categories []category.Category
+	exclude    bool
+}
+
+// Detect 检查文本是否包含敏感词
+func (s *Server) Detect(_ context.Context, req *swdpb.DetectRequest) (*swdpb.DetectResponse, error) {
+	f, err := s.parseFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	return &swdpb.DetectResponse{Detected: s.detect(req.GetText(), f)}, nil
+}
+
+// MatchAll 返回文本中的所有敏感词
+func (s *Server) MatchAll(_ context.Context, req *swdpb.MatchAllRequest) (*swdpb.MatchAllResponse, error) {
+	f, err := s.parseFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	return &swdpb.MatchAllResponse{Matches: s.toMatches(s.matchAll(req.GetText(), f))}, nil
+}
+
+// Replace 替换文本中的敏感词
+func (s *Server) Replace(_ context.Context, req *swdpb.ReplaceRequest) (*swdpb.ReplaceResponse, error) {
+	f, err := s.parseFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	return &swdpb.ReplaceResponse{Text: s.replace(req.GetText(), f, req.GetReplacement())}, nil
+}
+
+// Score 计算文本的风险评分
+func (s *Server) Score(_ context.Context, req *swdpb.ScoreRequest) (*swdpb.ScoreResponse, error) {
+	score := s.engine.Score(req.GetText())
+	reg := s.engine.Categories()
+	resp := &swdpb.ScoreResponse{
+		Total:      score.Total,
+		Categories: make(map[string]float64, len(score.Categories)),
+		Matches:    s.toMatches(score.Matches),
+	}
+	for cat, value := range score.Categories {
+		resp.Categories[reg.Format(cat)] = value
+	}
+	return resp, nil
+}
+
+// AddWords 批量添加词条
+func (s *Server) AddWords(_ context.Context, req *swdpb.AddWordsRequest) (*swdpb.AddWordsResponse, error) {
+	reg := s.engine.Categories()
+	words := make(map[string]category.Category, len(req.GetWords()))
+	for _, w := range req.GetWords() {
+		cat := category.Custom
+		if w.GetCategory() != "" {
+			parsed, err := reg.ParseText(w.GetCategory())
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			cat = parsed
+		}
+		words[w.GetWord()] = cat
+	}
+	if err := s.engine.AddWords(words); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &swdpb.AddWordsResponse{Added: int32(len(words))}, nil
+}
+
+// RemoveWords 批量删除词条
+func (s *Server) RemoveWords(_ context.Context, req *swdpb.RemoveWordsRequest) (*swdpb.RemoveWordsResponse, error) {
+	if err := s.engine.RemoveWords(req.GetWords()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &swdpb.RemoveWordsResponse{Removed: int32(len(req.GetWords()))}, nil
+}
+
+// GetWord 查询词条的分类与元数据
+func (s *Server) GetWord(_ context.Context, req *swdpb.GetWordRequest) (*swdpb.GetWordResponse, error) {
+	categories := s.engine.GetWordCategories(req.GetWord())
+	if categories == nil {
+		return nil, status.Errorf(codes.NotFound, "word %q not found", req.GetWord())
+	}
+	resp := &swdpb.GetWordResponse{Word: req.GetWord(), Categories: s.formatAll(categories)}
+	if meta, ok := s.engine.GetWordMeta(req.GetWord()); ok {
+		resp.Meta = toMeta(&meta)
+	}
+	return resp, nil
+}
+
+// Moderate 双向流式审核，单条消息出错时在响应中返回错误而不中断流
+func (s *Server) Moderate(stream grpc.BidiStreamingServer[swdpb.ModerateRequest, swdpb.ModerateResponse]) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp := &swdpb.ModerateResponse{Id: req.GetId()}
+		if f, err := s.parseFilter(req.GetFilter()); err != nil {
+			resp.Error = status.Convert(err).Message()
+		} else {
+			matches := s.matchAll(req.GetText(), f)
+			resp.Detected = len(matches) > 0
+			resp.Matches = s.toMatches(matches)
+			if req.GetReplacement() != "" {
+				resp.Text = s.replace(req.GetText(), f, req.GetReplacement())
+			}
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+// parseFilter 解析分类过滤条件，错误为 InvalidArgument 状态
+func (s *Server) parseFilter(pf *swdpb.CategoryFilter) (filter, error) {
+	include, exclude := pf.GetInclude(), pf.GetExclude()
+	if len(include) > 0 && len(exclude) > 0 {
+		return filter{}, status.Error(codes.InvalidArgument, errConflictingFilters.Error())
+	}
+	names, isExclude := include, false
+	if len(exclude) > 0 {
+		names, isExclude = exclude, true
+	}
+	if len(names) == 0 {
+		return filter{}, nil
+	}
+
+	reg := s.engine.Categories()
+	f := filter{categories: make([]category.Category, 0, len(names)), exclude: isExclude}
+	for _, name := range names {
+		cat, err := reg.ParseText(name)
+		if err != nil {
+			return filter{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		f.categories = append(f.categories, cat)
+	}
+	return f, nil
+}
+
+// detect 按过滤条件检测
+func (s *Server) detect(text string, f filter) bool {
+	switch {
+	case f.categories == nil:
+		return s.engine.Detect(text)
+	case f.exclude:
+		return s.engine.DetectNotIn(text, f.categories...)
+	default:
+		return s.engine.DetectIn(text, f.categories...)
+	}
+}
+
+// matchAll 按过滤条件匹配
+func (s *Server) matchAll(text string, f filter) []core.SensitiveWord {
+	switch {
+	case f.categories == nil:
+		return s.engine.MatchAll(text)
+	case f.exclude:
+		return s.engine.MatchAllNotIn(text, f.categories...)
+	default:
+		return s.engine.MatchAllIn(text, f.categories...)
+	}
+}
+
+// replace 按过滤条件替换，单个字符逐字替换，否则整词替换，默认为 *
+func (s *Server) replace(text string, f filter, replacement string) string {
+	strategy := swd.MaskStrategy(replacement)
+	switch {
+	case f.categories == nil:
+		return s.engine.ReplaceWithStrategy(text, strategy)
+	case f.exclude:
+		return s.engine.ReplaceWithStrategyNotIn(text, strategy, f.categories...)
+	default:
+		return s.engine.ReplaceWithStrategyIn(text, strategy, f.categories...)
+	}
+}
+
+// toMatches 转换匹配结果
+func (s *Server) toMatches(matches []core.SensitiveWord) []*swdpb.Match {
+	reg := s.engine.Categories()
+	result := make([]*swdpb.Match, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, &swdpb.Match{
+			Word:        m.Word,
+			Start:       int32(m.StartPos),
+			End:         int32(m.EndPos),
+			Category:    reg.Format(m.Category),
+			Categories:  s.formatAll(m.Categories),
+			Meta:        toMeta(m.Meta),
+			DictVersion: m.DictVersion,
+		})
+	}
+	return result
+}
+
+// formatAll 将分类转换为标识
+func (s *Server) formatAll(categories []category.Category) []string {
+	if len(categories) == 0 {
+		return nil
+	}
+	reg := s.engine.Categories()
+	ids := make([]string, len(categories))
+	for i, cat := range categories {
+		ids[i] = reg.Format(cat)
+	}
+	return ids
+}
+
+// toMeta 转换词条元数据
+func toMeta(meta *core.WordMeta) *swdpb.WordMeta {
+	if meta == nil {
+		return nil
+	}
+	return &swdpb.WordMeta{
+		Severity:    int32(meta.Severity),
+		Action:      meta.Action,
+		Replacement: meta.Replacement,
+		Tags:        meta.Tags,
+		Note:        meta.Note,
+	}
+}
@@ -0,0 +1,185 @@
+package grpcserver
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ttofTnT/go-swd/pkg/grpcserver/swdpb"
+	"github.com/ttofTnT/go-swd/pkg/swd"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// newClient 通过 bufconn 启动服务并返回客户端
+func newClient(t *testing.T) swdpb.SensitiveWordServiceClient {
+	t.Helper()
+	engine, err := swd.NewWithOptions(swd.WithProfile(swd.ProfileDefault))
+	if err != nil {
+		t.Fatalf("创建引擎失败: %v", err)
+	}
+	_ = engine.Clear()
+	if err := engine.AddWords(map[string]category.Category{
+		"赌场": category.Gambling,
+		"傻瓜": category.Profanity,
+	}); err != nil {
+		t.Fatalf("添加词条失败: %v", err)
+	}
+
+	srv, err := NewServer(engine)
+	if err != nil {
+		t.Fatalf("NewServer() 错误 = %v", err)
+	}
+	listener := bufconn.Listen(1 << 20)
+	g := grpc.NewServer()
+	srv.Register(g)
+	go func() { _ = g.Serve(listener) }()
+	t.Cleanup(g.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("连接失败: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return swdpb.NewSensitiveWordServiceClient(conn)
+}
+
+func TestNewServer(t *testing.T) {
+	if _, err := NewServer(nil); err != ErrNoEngine {
+		t.Errorf("NewServer(nil) 错误 = %v, 期望 %v", err, ErrNoEngine)
+	}
+}
+
+func TestServer_Unary(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	detected, err := client.Detect(ctx, &swdpb.DetectRequest{Text: "去赌场", Filter: &swdpb.CategoryFilter{Include: []string{"gambling"}}})
+	if err != nil || !detected.GetDetected() {
+		t.Errorf("Detect() = %v, %v, 期望命中", detected, err)
+	}
+
+	matched, err := client.MatchAll(ctx, &swdpb.MatchAllRequest{Text: "傻瓜去赌场", Filter: &swdpb.CategoryFilter{Exclude: []string{"profanity"}}})
+	if err != nil || len(matched.GetMatches()) != 1 {
+		t.Fatalf("MatchAll() = %v, %v, 期望 1 个匹配", matched, err)
+	}
+	if m := matched.GetMatches()[0]; m.GetWord() != "赌场" || m.GetCategory() != "gambling" || m.GetStart() != 3 || m.GetEnd() != 5 {
+		t.Errorf("MatchAll()[0] = %v", m)
+	}
+
+	replaced, err := client.Replace(ctx, &swdpb.ReplaceRequest{Text: "傻瓜去赌场", Replacement: "[屏蔽]"})
+	if err != nil || replaced.GetText() != "[屏蔽]去[屏蔽]" {
+		t.Errorf("Replace() = %v, %v", replaced, err)
+	}
+	// 替换覆盖原文区间，包括被预处理删除的零宽字符
+	replaced, err = client.Replace(ctx, &swdpb.ReplaceRequest{Text: "傻\u200b瓜去赌场"})
+	if err != nil || replaced.GetText() != "***去**" {
+		t.Errorf("Replace() = %v, %v, 期望 ***去**", replaced, err)
+	}
+
+	score, err := client.Score(ctx, &swdpb.ScoreRequest{Text: "傻瓜去赌场"})
+	if err != nil || score.GetTotal() != 1 || score.GetCategories()["gambling"] != 1 {
+		t.Errorf("Score() = %v, %v", score, err)
+	}
+
+	_, err = client.Detect(ctx, &swdpb.DetectRequest{Text: "x", Filter: &swdpb.CategoryFilter{Include: []string{"unknown"}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("未知分类错误码 = %v, 期望 InvalidArgument", status.Code(err))
+	}
+	_, err = client.Detect(ctx, &swdpb.DetectRequest{Text: "x", Filter: &swdpb.CategoryFilter{Include: []string{"scam"}, Exclude: []string{"drugs"}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("过滤条件冲突错误码 = %v, 期望 InvalidArgument", status.Code(err))
+	}
+}
+
+func TestServer_Words(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	added, err := client.AddWords(ctx, &swdpb.AddWordsRequest{Words: []*swdpb.Word{{Word: "老千", Category: "赌博"}, {Word: "竞品"}}})
+	if err != nil || added.GetAdded() != 2 {
+		t.Fatalf("AddWords() = %v, %v", added, err)
+	}
+	word, err := client.GetWord(ctx, &swdpb.GetWordRequest{Word: "竞品"})
+	if err != nil || len(word.GetCategories()) != 1 || word.GetCategories()[0] != "custom" {
+		t.Errorf("GetWord() = %v, %v, 期望分类 custom", word, err)
+	}
+
+	if _, err := client.AddWords(ctx, &swdpb.AddWordsRequest{Words: []*swdpb.Word{{Word: "x", Category: "unknown"}}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("未知分类错误码 = %v, 期望 InvalidArgument", status.Code(err))
+	}
+
+	if _, err := client.RemoveWords(ctx, &swdpb.RemoveWordsRequest{Words: []string{"老千"}}); err != nil {
+		t.Fatalf("RemoveWords() 错误 = %v", err)
+	}
+	if _, err := client.GetWord(ctx, &swdpb.GetWordRequest{Word: "老千"}); status.Code(err) != codes.NotFound {
+		t.Errorf("删除后查询错误码 = %v, 期望 NotFound", status.Code(err))
+	}
+}
+
+func TestServer_Moderate(t *testing.T) {
+	client := newClient(t)
+	stream, err := client.Moderate(context.Background())
+	if err != nil {
+		t.Fatalf("Moderate() 错误 = %v", err)
+	}
+
+	requests := []*swdpb.ModerateRequest{
+		{Id: "1", Text: "你好"},
+		{Id: "2", Text: "去赌场吗", Replacement: "*"},
+		{Id: "3", Text: "傻瓜", Filter: &swdpb.CategoryFilter{Include: []string{"unknown"}}},
+		{Id: "4", Text: "傻瓜", Filter: &swdpb.CategoryFilter{Include: []string{"profanity"}}},
+	}
+	go func() {
+		for _, req := range requests {
+			if err := stream.Send(req); err != nil {
+				return
+			}
+		}
+		_ = stream.CloseSend()
+	}()
+
+	var responses []*swdpb.ModerateResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() 错误 = %v", err)
+		}
+		responses = append(responses, resp)
+	}
+
+	if len(responses) != len(requests) {
+		t.Fatalf("收到 %d 条响应, 期望 %d", len(responses), len(requests))
+	}
+	for i, resp := range responses {
+		if resp.GetId() != requests[i].GetId() {
+			t.Errorf("第 %d 条响应 Id = %q, 期望按请求顺序返回 %q", i, resp.GetId(), requests[i].GetId())
+		}
+	}
+	if responses[0].GetDetected() {
+		t.Error("正常消息不应命中")
+	}
+	if !responses[1].GetDetected() || responses[1].GetText() != "去**吗" {
+		t.Errorf("第 2 条响应 = %v", responses[1])
+	}
+	if responses[2].GetError() == "" {
+		t.Error("非法过滤条件应在响应中返回错误")
+	}
+	if !responses[3].GetDetected() || responses[3].GetText() != "" {
+		t.Errorf("第 4 条响应 = %v", responses[3])
+	}
+}
@@ -0,0 +1,1324 @@
+// 敏感词检测服务的 gRPC 接口定义
+//
+// 分类均以 ASCII 标识表示（如 "gambling"），也接受分类名称。
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: swd.proto
+
+package swdpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// CategoryFilter 分类过滤条件，include 与 exclude 不能同时使用，均为空时不过滤
+type CategoryFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 仅处理这些分类
+	Include []string `protobuf:"bytes,1,rep,name=include,proto3" json:"include,omitempty"`
+	// 排除这些分类
+	Exclude       []string `protobuf:"bytes,2,rep,name=exclude,proto3" json:"exclude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryFilter) Reset() {
+	*x = CategoryFilter{}
+	mi := &file_swd_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryFilter) ProtoMessage() {}
+
+func (x *CategoryFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryFilter.ProtoReflect.Descriptor instead.
+func (*CategoryFilter) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CategoryFilter) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *CategoryFilter) GetExclude() []string {
+	if x != nil {
+		return x.Exclude
+	}
+	return nil
+}
+
+// WordMeta 词条元数据
+type WordMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Severity      int32                  `protobuf:"varint,1,opt,name=severity,proto3" json:"severity,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Replacement   string                 `protobuf:"bytes,3,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	Tags          []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Note          string                 `protobuf:"bytes,5,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WordMeta) Reset() {
+	*x = WordMeta{}
+	mi := &file_swd_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WordMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordMeta) ProtoMessage() {}
+
+func (x *WordMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordMeta.ProtoReflect.Descriptor instead.
+func (*WordMeta) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WordMeta) GetSeverity() int32 {
+	if x != nil {
+		return x.Severity
+	}
+	return 0
+}
+
+func (x *WordMeta) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *WordMeta) GetReplacement() string {
+	if x != nil {
+		return x.Replacement
+	}
+	return ""
+}
+
+func (x *WordMeta) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *WordMeta) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// Match 单个匹配结果，位置为预处理后文本中的字符偏移（左闭右开）
+type Match struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Word  string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Start int32                  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   int32                  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// 主分类
+	Category string `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	// 属于多个分类时的全部分类
+	Categories    []string  `protobuf:"bytes,5,rep,name=categories,proto3" json:"categories,omitempty"`
+	Meta          *WordMeta `protobuf:"bytes,6,opt,name=meta,proto3" json:"meta,omitempty"`
+	DictVersion   uint64    `protobuf:"varint,7,opt,name=dict_version,json=dictVersion,proto3" json:"dict_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Match) Reset() {
+	*x = Match{}
+	mi := &file_swd_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Match) ProtoMessage() {}
+
+func (x *Match) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Match.ProtoReflect.Descriptor instead.
+func (*Match) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Match) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Match) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Match) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *Match) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Match) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Match) GetMeta() *WordMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *Match) GetDictVersion() uint64 {
+	if x != nil {
+		return x.DictVersion
+	}
+	return 0
+}
+
+type DetectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Filter        *CategoryFilter        `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetectRequest) Reset() {
+	*x = DetectRequest{}
+	mi := &file_swd_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectRequest) ProtoMessage() {}
+
+func (x *DetectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectRequest.ProtoReflect.Descriptor instead.
+func (*DetectRequest) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DetectRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DetectRequest) GetFilter() *CategoryFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type DetectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Detected      bool                   `protobuf:"varint,1,opt,name=detected,proto3" json:"detected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetectResponse) Reset() {
+	*x = DetectResponse{}
+	mi := &file_swd_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectResponse) ProtoMessage() {}
+
+func (x *DetectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectResponse.ProtoReflect.Descriptor instead.
+func (*DetectResponse) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DetectResponse) GetDetected() bool {
+	if x != nil {
+		return x.Detected
+	}
+	return false
+}
+
+type MatchAllRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Filter        *CategoryFilter        `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchAllRequest) Reset() {
+	*x = MatchAllRequest{}
+	mi := &file_swd_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchAllRequest) ProtoMessage() {}
+
+func (x *MatchAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchAllRequest.ProtoReflect.Descriptor instead.
+func (*MatchAllRequest) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MatchAllRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *MatchAllRequest) GetFilter() *CategoryFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type MatchAllResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*Match               `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchAllResponse) Reset() {
+	*x = MatchAllResponse{}
+	mi := &file_swd_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchAllResponse) ProtoMessage() {}
+
+func (x *MatchAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchAllResponse.ProtoReflect.Descriptor instead.
+func (*MatchAllResponse) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MatchAllResponse) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type ReplaceRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Text   string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Filter *CategoryFilter        `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// 替换内容：单个字符逐字替换，否则整词替换，默认为 *
+	Replacement   string `protobuf:"bytes,3,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceRequest) Reset() {
+	*x = ReplaceRequest{}
+	mi := &file_swd_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceRequest) ProtoMessage() {}
+
+func (x *ReplaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceRequest) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReplaceRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ReplaceRequest) GetFilter() *CategoryFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ReplaceRequest) GetReplacement() string {
+	if x != nil {
+		return x.Replacement
+	}
+	return ""
+}
+
+type ReplaceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceResponse) Reset() {
+	*x = ReplaceResponse{}
+	mi := &file_swd_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceResponse) ProtoMessage() {}
+
+func (x *ReplaceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceResponse.ProtoReflect.Descriptor instead.
+func (*ReplaceResponse) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReplaceResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ScoreRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreRequest) Reset() {
+	*x = ScoreRequest{}
+	mi := &file_swd_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreRequest) ProtoMessage() {}
+
+func (x *ScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreRequest.ProtoReflect.Descriptor instead.
+func (*ScoreRequest) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ScoreRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ScoreResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Total float64                `protobuf:"fixed64,1,opt,name=total,proto3" json:"total,omitempty"`
+	// 分类标识 => 得分
+	Categories    map[string]float64 `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Matches       []*Match           `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreResponse) Reset() {
+	*x = ScoreResponse{}
+	mi := &file_swd_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreResponse) ProtoMessage() {}
+
+func (x *ScoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreResponse.ProtoReflect.Descriptor instead.
+func (*ScoreResponse) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ScoreResponse) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ScoreResponse) GetCategories() map[string]float64 {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ScoreResponse) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+// Word 待添加的词条，未指定分类时为 custom
+type Word struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Word) Reset() {
+	*x = Word{}
+	mi := &file_swd_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Word) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Word) ProtoMessage() {}
+
+func (x *Word) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Word.ProtoReflect.Descriptor instead.
+func (*Word) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Word) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Word) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type AddWordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Words         []*Word                `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWordsRequest) Reset() {
+	*x = AddWordsRequest{}
+	mi := &file_swd_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWordsRequest) ProtoMessage() {}
+
+func (x *AddWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWordsRequest.ProtoReflect.Descriptor instead.
+func (*AddWordsRequest) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AddWordsRequest) GetWords() []*Word {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type AddWordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Added         int32                  `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWordsResponse) Reset() {
+	*x = AddWordsResponse{}
+	mi := &file_swd_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWordsResponse) ProtoMessage() {}
+
+func (x *AddWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWordsResponse.ProtoReflect.Descriptor instead.
+func (*AddWordsResponse) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AddWordsResponse) GetAdded() int32 {
+	if x != nil {
+		return x.Added
+	}
+	return 0
+}
+
+type RemoveWordsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Words         []string               `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWordsRequest) Reset() {
+	*x = RemoveWordsRequest{}
+	mi := &file_swd_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWordsRequest) ProtoMessage() {}
+
+func (x *RemoveWordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWordsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWordsRequest) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RemoveWordsRequest) GetWords() []string {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type RemoveWordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Removed       int32                  `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWordsResponse) Reset() {
+	*x = RemoveWordsResponse{}
+	mi := &file_swd_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWordsResponse) ProtoMessage() {}
+
+func (x *RemoveWordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWordsResponse.ProtoReflect.Descriptor instead.
+func (*RemoveWordsResponse) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RemoveWordsResponse) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+type GetWordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWordRequest) Reset() {
+	*x = GetWordRequest{}
+	mi := &file_swd_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWordRequest) ProtoMessage() {}
+
+func (x *GetWordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWordRequest.ProtoReflect.Descriptor instead.
+func (*GetWordRequest) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetWordRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+type GetWordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Categories    []string               `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"`
+	Meta          *WordMeta              `protobuf:"bytes,3,opt,name=meta,proto3" json:"meta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWordResponse) Reset() {
+	*x = GetWordResponse{}
+	mi := &file_swd_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWordResponse) ProtoMessage() {}
+
+func (x *GetWordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWordResponse.ProtoReflect.Descriptor instead.
+func (*GetWordResponse) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetWordResponse) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *GetWordResponse) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *GetWordResponse) GetMeta() *WordMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+// ModerateRequest 流式审核的单条消息
+type ModerateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 调用方的消息标识，原样返回
+	Id     string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text   string          `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Filter *CategoryFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// 非空时在响应中返回替换后的文本，规则同 ReplaceRequest.replacement
+	Replacement   string `protobuf:"bytes,4,opt,name=replacement,proto3" json:"replacement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateRequest) Reset() {
+	*x = ModerateRequest{}
+	mi := &file_swd_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateRequest) ProtoMessage() {}
+
+func (x *ModerateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateRequest.ProtoReflect.Descriptor instead.
+func (*ModerateRequest) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ModerateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ModerateRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ModerateRequest) GetFilter() *CategoryFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ModerateRequest) GetReplacement() string {
+	if x != nil {
+		return x.Replacement
+	}
+	return ""
+}
+
+// ModerateResponse 流式审核的单条结果
+type ModerateResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Detected bool                   `protobuf:"varint,2,opt,name=detected,proto3" json:"detected,omitempty"`
+	Matches  []*Match               `protobuf:"bytes,3,rep,name=matches,proto3" json:"matches,omitempty"`
+	// 替换后的文本，请求未指定 replacement 时为空
+	Text string `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	// 该条消息处理失败时的错误信息，流不会因此中断
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModerateResponse) Reset() {
+	*x = ModerateResponse{}
+	mi := &file_swd_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerateResponse) ProtoMessage() {}
+
+func (x *ModerateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_swd_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerateResponse.ProtoReflect.Descriptor instead.
+func (*ModerateResponse) Descriptor() ([]byte, []int) {
+	return file_swd_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ModerateResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ModerateResponse) GetDetected() bool {
+	if x != nil {
+		return x.Detected
+	}
+	return false
+}
+
+func (x *ModerateResponse) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *ModerateResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ModerateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_swd_proto protoreflect.FileDescriptor
+
+const file_swd_proto_rawDesc = "" +
+	"\n" +
+	"\tswd.proto\x12\x06swd.v1\"D\n" +
+	"\x0eCategoryFilter\x12\x18\n" +
+	"\ainclude\x18\x01 \x03(\tR\ainclude\x12\x18\n" +
+	"\aexclude\x18\x02 \x03(\tR\aexclude\"\x88\x01\n" +
+	"\bWordMeta\x12\x1a\n" +
+	"\bseverity\x18\x01 \x01(\x05R\bseverity\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12 \n" +
+	"\vreplacement\x18\x03 \x01(\tR\vreplacement\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x12\n" +
+	"\x04note\x18\x05 \x01(\tR\x04note\"\xc8\x01\n" +
+	"\x05Match\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\x05R\x03end\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x05 \x03(\tR\n" +
+	"categories\x12$\n" +
+	"\x04meta\x18\x06 \x01(\v2\x10.swd.v1.WordMetaR\x04meta\x12!\n" +
+	"\fdict_version\x18\a \x01(\x04R\vdictVersion\"S\n" +
+	"\rDetectRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.swd.v1.CategoryFilterR\x06filter\",\n" +
+	"\x0eDetectResponse\x12\x1a\n" +
+	"\bdetected\x18\x01 \x01(\bR\bdetected\"U\n" +
+	"\x0fMatchAllRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.swd.v1.CategoryFilterR\x06filter\";\n" +
+	"\x10MatchAllResponse\x12'\n" +
+	"\amatches\x18\x01 \x03(\v2\r.swd.v1.MatchR\amatches\"v\n" +
+	"\x0eReplaceRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12.\n" +
+	"\x06filter\x18\x02 \x01(\v2\x16.swd.v1.CategoryFilterR\x06filter\x12 \n" +
+	"\vreplacement\x18\x03 \x01(\tR\vreplacement\"%\n" +
+	"\x0fReplaceResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"\"\n" +
+	"\fScoreRequest\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\"\xd4\x01\n" +
+	"\rScoreResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x01R\x05total\x12E\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\v2%.swd.v1.ScoreResponse.CategoriesEntryR\n" +
+	"categories\x12'\n" +
+	"\amatches\x18\x03 \x03(\v2\r.swd.v1.MatchR\amatches\x1a=\n" +
+	"\x0fCategoriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"6\n" +
+	"\x04Word\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\"5\n" +
+	"\x0fAddWordsRequest\x12\"\n" +
+	"\x05words\x18\x01 \x03(\v2\f.swd.v1.WordR\x05words\"(\n" +
+	"\x10AddWordsResponse\x12\x14\n" +
+	"\x05added\x18\x01 \x01(\x05R\x05added\"*\n" +
+	"\x12RemoveWordsRequest\x12\x14\n" +
+	"\x05words\x18\x01 \x03(\tR\x05words\"/\n" +
+	"\x13RemoveWordsResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\x05R\aremoved\"$\n" +
+	"\x0eGetWordRequest\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\"k\n" +
+	"\x0fGetWordResponse\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\tR\n" +
+	"categories\x12$\n" +
+	"\x04meta\x18\x03 \x01(\v2\x10.swd.v1.WordMetaR\x04meta\"\x87\x01\n" +
+	"\x0fModerateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12.\n" +
+	"\x06filter\x18\x03 \x01(\v2\x16.swd.v1.CategoryFilterR\x06filter\x12 \n" +
+	"\vreplacement\x18\x04 \x01(\tR\vreplacement\"\x91\x01\n" +
+	"\x10ModerateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bdetected\x18\x02 \x01(\bR\bdetected\x12'\n" +
+	"\amatches\x18\x03 \x03(\v2\r.swd.v1.MatchR\amatches\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error2\x86\x04\n" +
+	"\x14SensitiveWordService\x127\n" +
+	"\x06Detect\x12\x15.swd.v1.DetectRequest\x1a\x16.swd.v1.DetectResponse\x12=\n" +
+	"\bMatchAll\x12\x17.swd.v1.MatchAllRequest\x1a\x18.swd.v1.MatchAllResponse\x12:\n" +
+	"\aReplace\x12\x16.swd.v1.ReplaceRequest\x1a\x17.swd.v1.ReplaceResponse\x124\n" +
+	"\x05Score\x12\x14.swd.v1.ScoreRequest\x1a\x15.swd.v1.ScoreResponse\x12=\n" +
+	"\bAddWords\x12\x17.swd.v1.AddWordsRequest\x1a\x18.swd.v1.AddWordsResponse\x12F\n" +
+	"\vRemoveWords\x12\x1a.swd.v1.RemoveWordsRequest\x1a\x1b.swd.v1.RemoveWordsResponse\x12:\n" +
+	"\aGetWord\x12\x16.swd.v1.GetWordRequest\x1a\x17.swd.v1.GetWordResponse\x12A\n" +
+	"\bModerate\x12\x17.swd.v1.ModerateRequest\x1a\x18.swd.v1.ModerateResponse(\x010\x01B0Z.github.com/ttofTnT/go-swd/pkg/grpcserver/swdpbb\x06proto3"
+
+var (
+	file_swd_proto_rawDescOnce sync.Once
+	file_swd_proto_rawDescData []byte
+)
+
+func file_swd_proto_rawDescGZIP() []byte {
+	file_swd_proto_rawDescOnce.Do(func() {
+		file_swd_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_swd_proto_rawDesc), len(file_swd_proto_rawDesc)))
+	})
+	return file_swd_proto_rawDescData
+}
+
+var file_swd_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_swd_proto_goTypes = []any{
+	(*CategoryFilter)(nil),      // 0: swd.v1.CategoryFilter
+	(*WordMeta)(nil),            // 1: swd.v1.WordMeta
+	(*Match)(nil),               // 2: swd.v1.Match
+	(*DetectRequest)(nil),       // 3: swd.v1.DetectRequest
+	(*DetectResponse)(nil),      // 4: swd.v1.DetectResponse
+	(*MatchAllRequest)(nil),     // 5: swd.v1.MatchAllRequest
+	(*MatchAllResponse)(nil),    // 6: swd.v1.MatchAllResponse
+	(*ReplaceRequest)(nil),      // 7: swd.v1.ReplaceRequest
+	(*ReplaceResponse)(nil),     // 8: swd.v1.ReplaceResponse
+	(*ScoreRequest)(nil),        // 9: swd.v1.ScoreRequest
+	(*ScoreResponse)(nil),       // 10: swd.v1.ScoreResponse
+	(*Word)(nil),                // 11: swd.v1.Word
+	(*AddWordsRequest)(nil),     // 12: swd.v1.AddWordsRequest
+	(*AddWordsResponse)(nil),    // 13: swd.v1.AddWordsResponse
+	(*RemoveWordsRequest)(nil),  // 14: swd.v1.RemoveWordsRequest
+	(*RemoveWordsResponse)(nil), // 15: swd.v1.RemoveWordsResponse
+	(*GetWordRequest)(nil),      // 16: swd.v1.GetWordRequest
+	(*GetWordResponse)(nil),     // 17: swd.v1.GetWordResponse
+	(*ModerateRequest)(nil),     // 18: swd.v1.ModerateRequest
+	(*ModerateResponse)(nil),    // 19: swd.v1.ModerateResponse
+	nil,                         // 20: swd.v1.ScoreResponse.CategoriesEntry
+}
+var file_swd_proto_depIdxs = []int32{
+	1,  // 0: swd.v1.Match.meta:type_name -> swd.v1.WordMeta
+	0,  // 1: swd.v1.DetectRequest.filter:type_name -> swd.v1.CategoryFilter
+	0,  // 2: swd.v1.MatchAllRequest.filter:type_name -> swd.v1.CategoryFilter
+	2,  // 3: swd.v1.MatchAllResponse.matches:type_name -> swd.v1.Match
+	0,  // 4: swd.v1.ReplaceRequest.filter:type_name -> swd.v1.CategoryFilter
+	20, // 5: swd.v1.ScoreResponse.categories:type_name -> swd.v1.ScoreResponse.CategoriesEntry
+	2,  // 6: swd.v1.ScoreResponse.matches:type_name -> swd.v1.Match
+	11, // 7: swd.v1.AddWordsRequest.words:type_name -> swd.v1.Word
+	1,  // 8: swd.v1.GetWordResponse.meta:type_name -> swd.v1.WordMeta
+	0,  // 9: swd.v1.ModerateRequest.filter:type_name -> swd.v1.CategoryFilter
+	2,  // 10: swd.v1.ModerateResponse.matches:type_name -> swd.v1.Match
+	3,  // 11: swd.v1.SensitiveWordService.Detect:input_type -> swd.v1.DetectRequest
+	5,  // 12: swd.v1.SensitiveWordService.MatchAll:input_type -> swd.v1.MatchAllRequest
+	7,  // 13: swd.v1.SensitiveWordService.Replace:input_type -> swd.v1.ReplaceRequest
+	9,  // 14: swd.v1.SensitiveWordService.Score:input_type -> swd.v1.ScoreRequest
+	12, // 15: swd.v1.SensitiveWordService.AddWords:input_type -> swd.v1.AddWordsRequest
+	14, // 16: swd.v1.SensitiveWordService.RemoveWords:input_type -> swd.v1.RemoveWordsRequest
+	16, // 17: swd.v1.SensitiveWordService.GetWord:input_type -> swd.v1.GetWordRequest
+	18, // 18: swd.v1.SensitiveWordService.Moderate:input_type -> swd.v1.ModerateRequest
+	4,  // 19: swd.v1.SensitiveWordService.Detect:output_type -> swd.v1.DetectResponse
+	6,  // 20: swd.v1.SensitiveWordService.MatchAll:output_type -> swd.v1.MatchAllResponse
+	8,  // 21: swd.v1.SensitiveWordService.Replace:output_type -> swd.v1.ReplaceResponse
+	10, // 22: swd.v1.SensitiveWordService.Score:output_type -> swd.v1.ScoreResponse
+	13, // 23: swd.v1.SensitiveWordService.AddWords:output_type -> swd.v1.AddWordsResponse
+	15, // 24: swd.v1.SensitiveWordService.RemoveWords:output_type -> swd.v1.RemoveWordsResponse
+	17, // 25: swd.v1.SensitiveWordService.GetWord:output_type -> swd.v1.GetWordResponse
+	19, // 26: swd.v1.SensitiveWordService.Moderate:output_type -> swd.v1.ModerateResponse
+	19, // [19:27] is the sub-list for method output_type
+	11, // [11:19] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_swd_proto_init() }
+func file_swd_proto_init() {
+	if File_swd_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_swd_proto_rawDesc), len(file_swd_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_swd_proto_goTypes,
+		DependencyIndexes: file_swd_proto_depIdxs,
+		MessageInfos:      file_swd_proto_msgTypes,
+	}.Build()
+	File_swd_proto = out.File
+	file_swd_proto_goTypes = nil
+	file_swd_proto_depIdxs = nil
+}
@@ -0,0 +1,406 @@
+// 敏感词检测服务的 gRPC 接口定义
+//
+// 分类均以 ASCII 标识表示（如 "gambling"），也接受分类名称。
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: swd.proto
+
+package swdpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SensitiveWordService_Detect_FullMethodName      = "/swd.v1.SensitiveWordService/Detect"
+	SensitiveWordService_MatchAll_FullMethodName    = "/swd.v1.SensitiveWordService/MatchAll"
+	SensitiveWordService_Replace_FullMethodName     = "/swd.v1.SensitiveWordService/Replace"
+	SensitiveWordService_Score_FullMethodName       = "/swd.v1.SensitiveWordService/Score"
+	SensitiveWordService_AddWords_FullMethodName    = "/swd.v1.SensitiveWordService/AddWords"
+	SensitiveWordService_RemoveWords_FullMethodName = "/swd.v1.SensitiveWordService/RemoveWords"
+	SensitiveWordService_GetWord_FullMethodName     = "/swd.v1.SensitiveWordService/GetWord"
+	SensitiveWordService_Moderate_FullMethodName    = "/swd.v1.SensitiveWordService/Moderate"
+)
+
+// SensitiveWordServiceClient is the client API for SensitiveWordService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SensitiveWordService 敏感词检测、过滤与词库管理
+type SensitiveWordServiceClient interface {
+	// Detect 检查文本是否包含敏感词
+	Detect(ctx context.Context, in *DetectRequest, opts ...grpc.CallOption) (*DetectResponse, error)
+	// MatchAll 返回文本中的所有敏感词
+	MatchAll(ctx context.Context, in *MatchAllRequest, opts ...grpc.CallOption) (*MatchAllResponse, error)
+	// Replace 替换文本中的敏感词
+	Replace(ctx context.Context, in *ReplaceRequest, opts ...grpc.CallOption) (*ReplaceResponse, error)
+	// Score 计算文本的风险评分
+	Score(ctx context.Context, in *ScoreRequest, opts ...grpc.CallOption) (*ScoreResponse, error)
+	// AddWords 批量添加词条
+	AddWords(ctx context.Context, in *AddWordsRequest, opts ...grpc.CallOption) (*AddWordsResponse, error)
+	// RemoveWords 批量删除词条
+	RemoveWords(ctx context.Context, in *RemoveWordsRequest, opts ...grpc.CallOption) (*RemoveWordsResponse, error)
+	// GetWord 查询词条的分类与元数据
+	GetWord(ctx context.Context, in *GetWordRequest, opts ...grpc.CallOption) (*GetWordResponse, error)
+	// Moderate 双向流式审核，适合聊天消息流：每条请求对应一条响应，按请求顺序返回
+	Moderate(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ModerateRequest, ModerateResponse], error)
+}
+
+type sensitiveWordServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSensitiveWordServiceClient(cc grpc.ClientConnInterface) SensitiveWordServiceClient {
+	return &sensitiveWordServiceClient{cc}
+}
+
+func (c *sensitiveWordServiceClient) Detect(ctx context.Context, in *DetectRequest, opts ...grpc.CallOption) (*DetectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetectResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordService_Detect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensitiveWordServiceClient) MatchAll(ctx context.Context, in *MatchAllRequest, opts ...grpc.CallOption) (*MatchAllResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchAllResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordService_MatchAll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensitiveWordServiceClient) Replace(ctx context.Context, in *ReplaceRequest, opts ...grpc.CallOption) (*ReplaceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplaceResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordService_Replace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensitiveWordServiceClient) Score(ctx context.Context, in *ScoreRequest, opts ...grpc.CallOption) (*ScoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScoreResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordService_Score_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensitiveWordServiceClient) AddWords(ctx context.Context, in *AddWordsRequest, opts ...grpc.CallOption) (*AddWordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddWordsResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordService_AddWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensitiveWordServiceClient) RemoveWords(ctx context.Context, in *RemoveWordsRequest, opts ...grpc.CallOption) (*RemoveWordsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveWordsResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordService_RemoveWords_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensitiveWordServiceClient) GetWord(ctx context.Context, in *GetWordRequest, opts ...grpc.CallOption) (*GetWordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetWordResponse)
+	err := c.cc.Invoke(ctx, SensitiveWordService_GetWord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sensitiveWordServiceClient) Moderate(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ModerateRequest, ModerateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SensitiveWordService_ServiceDesc.Streams[0], SensitiveWordService_Moderate_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ModerateRequest, ModerateResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SensitiveWordService_ModerateClient = grpc.BidiStreamingClient[ModerateRequest, ModerateResponse]
+
+// SensitiveWordServiceServer is the server API for SensitiveWordService service.
+// All implementations must embed UnimplementedSensitiveWordServiceServer
+// for forward compatibility.
+//
+// SensitiveWordService 敏感词检测、过滤与词库管理
+type SensitiveWordServiceServer interface {
+	// Detect 检查文本是否包含敏感词
+	Detect(context.Context, *DetectRequest) (*DetectResponse, error)
+	// MatchAll 返回文本中的所有敏感词
+	MatchAll(context.Context, *MatchAllRequest) (*MatchAllResponse, error)
+	// Replace 替换文本中的敏感词
+	Replace(context.Context, *ReplaceRequest) (*ReplaceResponse, error)
+	// Score 计算文本的风险评分
+	Score(context.Context, *ScoreRequest) (*ScoreResponse, error)
+	// AddWords 批量添加词条
+	AddWords(context.Context, *AddWordsRequest) (*AddWordsResponse, error)
+	// RemoveWords 批量删除词条
+	RemoveWords(context.Context, *RemoveWordsRequest) (*RemoveWordsResponse, error)
+	// GetWord 查询词条的分类与元数据
+	GetWord(context.Context, *GetWordRequest) (*GetWordResponse, error)
+	// Moderate 双向流式审核，适合聊天消息流：每条请求对应一条响应，按请求顺序返回
+	Moderate(grpc.BidiStreamingServer[ModerateRequest, ModerateResponse]) error
+	mustEmbedUnimplementedSensitiveWordServiceServer()
+}
+
+// UnimplementedSensitiveWordServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSensitiveWordServiceServer struct{}
+
+func (UnimplementedSensitiveWordServiceServer) Detect(context.Context, *DetectRequest) (*DetectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Detect not implemented")
+}
+func (UnimplementedSensitiveWordServiceServer) MatchAll(context.Context, *MatchAllRequest) (*MatchAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatchAll not implemented")
+}
+func (UnimplementedSensitiveWordServiceServer) Replace(context.Context, *ReplaceRequest) (*ReplaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Replace not implemented")
+}
+func (UnimplementedSensitiveWordServiceServer) Score(context.Context, *ScoreRequest) (*ScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Score not implemented")
+}
+func (UnimplementedSensitiveWordServiceServer) AddWords(context.Context, *AddWordsRequest) (*AddWordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWords not implemented")
+}
+func (UnimplementedSensitiveWordServiceServer) RemoveWords(context.Context, *RemoveWordsRequest) (*RemoveWordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWords not implemented")
+}
+func (UnimplementedSensitiveWordServiceServer) GetWord(context.Context, *GetWordRequest) (*GetWordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWord not implemented")
+}
+func (UnimplementedSensitiveWordServiceServer) Moderate(grpc.BidiStreamingServer[ModerateRequest, ModerateResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Moderate not implemented")
+}
+func (UnimplementedSensitiveWordServiceServer) mustEmbedUnimplementedSensitiveWordServiceServer() {}
+func (UnimplementedSensitiveWordServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeSensitiveWordServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SensitiveWordServiceServer will
+// result in compilation errors.
+type UnsafeSensitiveWordServiceServer interface {
+	mustEmbedUnimplementedSensitiveWordServiceServer()
+}
+
+func RegisterSensitiveWordServiceServer(s grpc.ServiceRegistrar, srv SensitiveWordServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSensitiveWordServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SensitiveWordService_ServiceDesc, srv)
+}
+
+func _SensitiveWordService_Detect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordServiceServer).Detect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordService_Detect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordServiceServer).Detect(ctx, req.(*DetectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensitiveWordService_MatchAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchAllRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordServiceServer).MatchAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordService_MatchAll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordServiceServer).MatchAll(ctx, req.(*MatchAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensitiveWordService_Replace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordServiceServer).Replace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordService_Replace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordServiceServer).Replace(ctx, req.(*ReplaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensitiveWordService_Score_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordServiceServer).Score(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordService_Score_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordServiceServer).Score(ctx, req.(*ScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensitiveWordService_AddWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordServiceServer).AddWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordService_AddWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordServiceServer).AddWords(ctx, req.(*AddWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensitiveWordService_RemoveWords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordServiceServer).RemoveWords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordService_RemoveWords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordServiceServer).RemoveWords(ctx, req.(*RemoveWordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensitiveWordService_GetWord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SensitiveWordServiceServer).GetWord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SensitiveWordService_GetWord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SensitiveWordServiceServer).GetWord(ctx, req.(*GetWordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SensitiveWordService_Moderate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SensitiveWordServiceServer).Moderate(&grpc.GenericServerStream[ModerateRequest, ModerateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SensitiveWordService_ModerateServer = grpc.BidiStreamingServer[ModerateRequest, ModerateResponse]
+
+// SensitiveWordService_ServiceDesc is the grpc.ServiceDesc for SensitiveWordService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SensitiveWordService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "swd.v1.SensitiveWordService",
+	HandlerType: (*SensitiveWordServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Detect",
+			Handler:    _SensitiveWordService_Detect_Handler,
+		},
+		{
+			MethodName: "MatchAll",
+			Handler:    _SensitiveWordService_MatchAll_Handler,
+		},
+		{
+			MethodName: "Replace",
+			Handler:    _SensitiveWordService_Replace_Handler,
+		},
+		{
+			MethodName: "Score",
+			Handler:    _SensitiveWordService_Score_Handler,
+		},
+		{
+			MethodName: "AddWords",
+			Handler:    _SensitiveWordService_AddWords_Handler,
+		},
+		{
+			MethodName: "RemoveWords",
+			Handler:    _SensitiveWordService_RemoveWords_Handler,
+		},
+		{
+			MethodName: "GetWord",
+			Handler:    _SensitiveWordService_GetWord_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Moderate",
+			Handler:       _SensitiveWordService_Moderate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "swd.proto",
+}
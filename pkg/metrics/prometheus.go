@@ -0,0 +1,205 @@
+// Package metrics 提供 core.Instrumentation 的 Prometheus 实现
+//
+// 用法：
+//
+//	m := metrics.NewPrometheus(metrics.PrometheusOpts{})
+//	prometheus.MustRegister(m)
+//	engine.SetInstrumentation(m)
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// PrometheusOpts Prometheus 指标的配置，零值可直接使用
+type PrometheusOpts struct {
+	Namespace      string             // 指标名前缀，默认 swd
+	ConstLabels    prometheus.Labels  // 附加到所有指标的固定标签，如实例名
+	Categories     *category.Registry // 生成 category 标签所用的注册表，默认 category.Default
+	ScanBuckets    []float64          // 检测与替换耗时直方图的桶（秒），默认 10µs 到约 2.6s
+	RebuildBuckets []float64          // 重建耗时直方图的桶（秒），默认 1ms 到约 33s
+}
+
+// Prometheus 以 Prometheus 指标记录检测、替换、重建和词库大小，同时实现 core.Instrumentation 和 prometheus.Collector
+//
+// 指标（以默认前缀 swd 为例）：
+//
+//	swd_scans_total{op}                    检测次数
+//	swd_scans_with_hits_total{op}          命中敏感词的检测次数，与 swd_scans_total 相除即命中率
+//	swd_scan_duration_seconds{op}          检测耗时
+//	swd_category_hits_total{category}      各分类被命中的次数，属于多个分类的词条在每个分类中各计一次
+//	swd_replacements_total                 被替换的敏感词数量
+//	swd_replace_duration_seconds           替换耗时
+//	swd_rebuild_duration_seconds           检测算法重建耗时
+//	swd_rebuild_errors_total               检测算法重建失败次数
+//	swd_dictionary_words                   词库词条总数
+//	swd_dictionary_category_words{category} 各分类的词条数
+type Prometheus struct {
+	categories *category.Registry
+
+	scans           *prometheus.CounterVec
+	scansWithHits   *prometheus.CounterVec
+	scanDuration    *prometheus.HistogramVec
+	categoryHits    *prometheus.CounterVec
+	replacements    prometheus.Counter
+	replaceDuration prometheus.Histogram
+	rebuildDuration prometheus.Histogram
+	rebuildErrors   prometheus.Counter
+	words           prometheus.Gauge
+	categoryWords   *prometheus.GaugeVec
+
+	dictMu         sync.Mutex
+	categoryLabels map[string]struct{} // 当前已设置的 dictionary_category_words 标签，受 dictMu 保护
+}
+
+// 编译期检查
+var (
+	_ core.Instrumentation = (*Prometheus)(nil)
+	_ prometheus.Collector = (*Prometheus)(nil)
+)
+
+// NewPrometheus 创建 Prometheus 指标，需自行注册到 prometheus.Registerer
+func NewPrometheus(opts PrometheusOpts) *Prometheus {
+	if opts.Namespace == "" {
+		opts.Namespace = "swd"
+	}
+	if opts.Categories == nil {
+		opts.Categories = category.Default
+	}
+	if opts.ScanBuckets == nil {
+		opts.ScanBuckets = prometheus.ExponentialBuckets(1e-5, 4, 10)
+	}
+	if opts.RebuildBuckets == nil {
+		opts.RebuildBuckets = prometheus.ExponentialBuckets(1e-3, 2, 16)
+	}
+	ns, labels := opts.Namespace, opts.ConstLabels
+
+	return &Prometheus{
+		categories: opts.Categories,
+		scans: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "scans_total", ConstLabels: labels,
+			Help: "Number of texts scanned, by detection method.",
+		}, []string{"op"}),
+		scansWithHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "scans_with_hits_total", ConstLabels: labels,
+			Help: "Number of scanned texts that contained at least one sensitive word, by detection method.",
+		}, []string{"op"}),
+		scanDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns, Name: "scan_duration_seconds", ConstLabels: labels, Buckets: opts.ScanBuckets,
+			Help: "Time spent scanning a text, by detection method.",
+		}, []string{"op"}),
+		categoryHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns, Name: "category_hits_total", ConstLabels: labels,
+			Help: "Number of sensitive word matches, by category.",
+		}, []string{"category"}),
+		replacements: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: ns, Name: "replacements_total", ConstLabels: labels,
+			Help: "Number of sensitive words replaced.",
+		}),
+		replaceDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns, Name: "replace_duration_seconds", ConstLabels: labels, Buckets: opts.ScanBuckets,
+			Help: "Time spent replacing sensitive words in a text.",
+		}),
+		rebuildDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns, Name: "rebuild_duration_seconds", ConstLabels: labels, Buckets: opts.RebuildBuckets,
+			Help: "Time spent rebuilding the matching automaton after a dictionary change.",
+		}),
+		rebuildErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: ns, Name: "rebuild_errors_total", ConstLabels: labels,
+			Help: "Number of failed automaton rebuilds.",
+		}),
+		words: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns, Name: "dictionary_words", ConstLabels: labels,
+			Help: "Number of words in the dictionary.",
+		}),
+		categoryWords: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns, Name: "dictionary_category_words", ConstLabels: labels,
+			Help: "Number of words in the dictionary, by category.",
+		}, []string{"category"}),
+	}
+}
+
+// collectors 返回所有指标
+func (p *Prometheus) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		p.scans, p.scansWithHits, p.scanDuration, p.categoryHits,
+		p.replacements, p.replaceDuration,
+		p.rebuildDuration, p.rebuildErrors,
+		p.words, p.categoryWords,
+	}
+}
+
+// Describe 实现 prometheus.Collector
+func (p *Prometheus) Describe(ch chan<- *prometheus.Desc) {
+	for _, c := range p.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect 实现 prometheus.Collector
+func (p *Prometheus) Collect(ch chan<- prometheus.Metric) {
+	for _, c := range p.collectors() {
+		c.Collect(ch)
+	}
+}
+
+// ObserveScan 实现 core.Instrumentation
+func (p *Prometheus) ObserveScan(op string, matches []core.SensitiveWord, elapsed time.Duration) {
+	p.scans.WithLabelValues(op).Inc()
+	p.scanDuration.WithLabelValues(op).Observe(elapsed.Seconds())
+	if len(matches) == 0 {
+		return
+	}
+	p.scansWithHits.WithLabelValues(op).Inc()
+	for _, match := range matches {
+		for _, cat := range match.AllCategories() {
+			p.categoryHits.WithLabelValues(p.categories.Format(cat)).Inc()
+		}
+	}
+}
+
+// ObserveReplace 实现 core.Instrumentation
+func (p *Prometheus) ObserveReplace(replaced int, elapsed time.Duration) {
+	p.replacements.Add(float64(replaced))
+	p.replaceDuration.Observe(elapsed.Seconds())
+}
+
+// ObserveRebuild 实现 core.Instrumentation
+func (p *Prometheus) ObserveRebuild(_ int, elapsed time.Duration, err error) {
+	p.rebuildDuration.Observe(elapsed.Seconds())
+	if err != nil {
+		p.rebuildErrors.Inc()
+	}
+}
+
+// ObserveDictionary 实现 core.Instrumentation，已不存在的分类会被移除
+//
+// 各分类的值直接覆盖写入，只删除不再出现的分类，采集期间不会看到被清空的指标。
+func (p *Prometheus) ObserveDictionary(total int, perCategory map[category.Category]int) {
+	counts := make(map[string]int, len(perCategory))
+	for cat, n := range perCategory {
+		counts[p.categories.Format(cat)] += n
+	}
+
+	p.dictMu.Lock()
+	defer p.dictMu.Unlock()
+	p.words.Set(float64(total))
+	for label, n := range counts {
+		p.categoryWords.WithLabelValues(label).Set(float64(n))
+	}
+	for label := range p.categoryLabels {
+		if _, ok := counts[label]; !ok {
+			p.categoryWords.DeleteLabelValues(label)
+		}
+	}
+	p.categoryLabels = make(map[string]struct{}, len(counts))
+	for label := range counts {
+		p.categoryLabels[label] = struct{}{}
+	}
+}
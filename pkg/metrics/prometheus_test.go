@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/ttofTnT/go-swd/pkg/swd"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+func TestPrometheus(t *testing.T) {
+	engine, err := swd.New(swd.NewDefaultFactory())
+	if err != nil {
+		t.Fatalf("创建引擎失败: %v", err)
+	}
+	_ = engine.Clear()
+
+	m := NewPrometheus(PrometheusOpts{ConstLabels: prometheus.Labels{"instance": "test"}})
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(m); err != nil {
+		t.Fatalf("注册指标失败: %v", err)
+	}
+	if err := engine.SetInstrumentation(m); err != nil {
+		t.Fatalf("SetInstrumentation() 错误 = %v", err)
+	}
+
+	if err := engine.AddWords(map[string]category.Category{
+		"赌场": category.Gambling,
+		"老千": category.Gambling,
+		"傻瓜": category.Profanity,
+	}); err != nil {
+		t.Fatalf("添加词条失败: %v", err)
+	}
+	engine.MatchAll("傻瓜去赌场")
+	engine.MatchAll("你好")
+	engine.Detect("老千")
+	engine.ReplaceWithAsterisk("傻瓜去赌场")
+
+	tests := []struct {
+		name      string
+		collector prometheus.Collector
+		want      float64
+	}{
+		{"MatchAll 检测次数", m.scans.WithLabelValues("MatchAll"), 3}, // 替换内部的检测也计入
+		{"MatchAll 命中次数", m.scansWithHits.WithLabelValues("MatchAll"), 2},
+		{"Detect 检测次数", m.scans.WithLabelValues("Detect"), 1},
+		{"gambling 命中次数", m.categoryHits.WithLabelValues("gambling"), 3},
+		{"profanity 命中次数", m.categoryHits.WithLabelValues("profanity"), 2},
+		{"替换数量", m.replacements, 2},
+		{"词库总数", m.words, 3},
+		{"gambling 词条数", m.categoryWords.WithLabelValues("gambling"), 2},
+		{"profanity 词条数", m.categoryWords.WithLabelValues("profanity"), 1},
+	}
+	for _, tt := range tests {
+		if got := testutil.ToFloat64(tt.collector); got != tt.want {
+			t.Errorf("%s = %v, 期望 %v", tt.name, got, tt.want)
+		}
+	}
+	if n := testutil.CollectAndCount(m, "swd_rebuild_duration_seconds"); n != 1 {
+		t.Errorf("重建耗时直方图数量 = %d, 期望 1", n)
+	}
+
+	// 删除分类下的全部词条后，该分类的词条数指标被移除
+	if err := engine.RemoveWord("傻瓜"); err != nil {
+		t.Fatalf("删除词条失败: %v", err)
+	}
+	if err := engine.AddWords(map[string]category.Category{"赌档": category.Gambling}); err != nil {
+		t.Fatalf("添加词条失败: %v", err)
+	}
+	if got := testutil.ToFloat64(m.words); got != 3 {
+		t.Errorf("删除后词库总数 = %v, 期望 3", got)
+	}
+	if n := testutil.CollectAndCount(m, "swd_dictionary_category_words"); n != 1 {
+		t.Errorf("分类词条数指标数量 = %d, 期望 1", n)
+	}
+
+	if _, err := testutil.GatherAndLint(reg); err != nil {
+		t.Errorf("指标检查失败: %v", err)
+	}
+}
+
+// TestPrometheus_ObserveDictionaryConcurrent 测试更新词库指标期间采集不会看到被清空的分类
+func TestPrometheus_ObserveDictionaryConcurrent(t *testing.T) {
+	m := NewPrometheus(PrometheusOpts{})
+	perCategory := map[category.Category]int{category.Gambling: 2, category.Scam: 1}
+	m.ObserveDictionary(3, perCategory)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			m.ObserveDictionary(3, perCategory)
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		if n := testutil.CollectAndCount(m, "swd_dictionary_category_words"); n != 2 {
+			t.Fatalf("分类词条数指标数量 = %d, 期望 2", n)
+		}
+	}
+	<-done
+}
@@ -0,0 +1,111 @@
+package policy
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+)
+
+// ErrNoDetector 没有提供检测器
+var ErrNoDetector = errors.New("no detector provided")
+
+// Verdict 文本处置结论
+type Verdict struct {
+	Action  Action               // 最终动作
+	Reasons []core.SensitiveWord // 决定最终动作的匹配结果
+}
+
+// Engine 策略引擎，根据策略将检测结果归并为单一处置结论
+type Engine struct {
+	detector core.Detector
+	policy   *compiled
+	mu       sync.RWMutex
+}
+
+// NewEngine 创建策略引擎，policy 为 nil 时使用默认策略
+func NewEngine(detector core.Detector, policy *Policy) (*Engine, error) {
+	if detector == nil {
+		return nil, ErrNoDetector
+	}
+	e := &Engine{detector: detector}
+	if err := e.SetPolicy(policy); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// SetPolicy 替换当前策略，校验失败时保留原策略
+func (e *Engine) SetPolicy(policy *Policy) error {
+	if policy == nil {
+		policy = &Policy{}
+	}
+	c, err := compile(policy)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.policy = c
+	e.mu.Unlock()
+	return nil
+}
+
+// Evaluate 检测文本并返回处置结论
+func (e *Engine) Evaluate(text string) Verdict {
+	return e.Decide(e.detector.MatchAll(text))
+}
+
+// Decide 根据已有的匹配结果给出处置结论
+func (e *Engine) Decide(matches []core.SensitiveWord) Verdict {
+	e.mu.RLock()
+	p := e.policy
+	e.mu.RUnlock()
+
+	if len(matches) == 0 {
+		return Verdict{Action: p.defaultAction}
+	}
+
+	actions := make([]Action, len(matches))
+	final := actions[0]
+	for i, match := range matches {
+		actions[i] = p.actionFor(match)
+		if i == 0 || p.stricter(actions[i], final) {
+			final = actions[i]
+		}
+	}
+
+	verdict := Verdict{Action: final}
+	for i, match := range matches {
+		if actions[i] == final {
+			verdict.Reasons = append(verdict.Reasons, match)
+		}
+	}
+	return verdict
+}
+
+// actionFor 确定单个匹配结果的动作
+func (c *compiled) actionFor(match core.SensitiveWord) Action {
+	if action, ok := c.words[match.Word]; ok {
+		return action
+	}
+	if match.Meta != nil && match.Meta.Action != "" {
+		if action, err := ParseAction(match.Meta.Action); err == nil {
+			return action
+		}
+	}
+
+	var (
+		action Action
+		found  bool
+	)
+	for _, rule := range c.categories {
+		if match.In(rule.cat) && (!found || c.stricter(rule.action, action)) {
+			action, found = rule.action, true
+		}
+	}
+	if found {
+		return action
+	}
+	return c.matchAction
+}
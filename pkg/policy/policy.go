@@ -0,0 +1,190 @@
+// Package policy 在检测器之上提供按词条/分类配置的处置策略
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// Action 处置动作
+type Action string
+
+const (
+	ActionPass   Action = "pass"   // 放行并记录
+	ActionMask   Action = "mask"   // 打码后放行
+	ActionReview Action = "review" // 转人工审核
+	ActionBlock  Action = "block"  // 拒绝
+)
+
+var (
+	// ErrUnknownAction 未知的处置动作
+	ErrUnknownAction = errors.New("unknown action")
+	// ErrUnknownCategory 未知的分类
+	ErrUnknownCategory = errors.New("unknown category")
+	// ErrInvalidPrecedence 优先级配置不合法
+	ErrInvalidPrecedence = errors.New("invalid precedence")
+)
+
+// defaultPrecedence 默认优先级，越靠前越严格
+var defaultPrecedence = []Action{ActionBlock, ActionReview, ActionMask, ActionPass}
+
+// ParseAction 解析处置动作，支持 reject/allow 等别名
+func ParseAction(s string) (Action, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "block", "reject":
+		return ActionBlock, nil
+	case "review":
+		return ActionReview, nil
+	case "mask":
+		return ActionMask, nil
+	case "pass", "allow", "log":
+		return ActionPass, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownAction, s)
+	}
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler 接口
+func (a *Action) UnmarshalText(text []byte) error {
+	action, err := ParseAction(string(text))
+	if err != nil {
+		return err
+	}
+	*a = action
+	return nil
+}
+
+// Policy 处置策略配置
+//
+// 单个匹配结果的动作按以下顺序确定：Words 中的词条规则、词库元数据中的 action、
+// Categories 中的分类规则、Match。多个匹配结果之间按 Precedence 取最严格的动作，
+// 没有任何匹配时使用 Default。
+type Policy struct {
+	Default    Action            `json:"default,omitempty"`    // 无匹配时的动作，默认 pass
+	Match      Action            `json:"match,omitempty"`      // 有匹配但无规则时的动作，默认 block
+	Categories map[string]Action `json:"categories,omitempty"` // 分类名称 => 动作
+	Words      map[string]Action `json:"words,omitempty"`      // 词条 => 动作
+	Precedence []Action          `json:"precedence,omitempty"` // 动作优先级，越靠前越严格
+}
+
+// Load 从 JSON 读取策略配置
+func Load(r io.Reader) (*Policy, error) {
+	var p Policy
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+	if _, err := compile(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// LoadFile 从 JSON 文件读取策略配置
+func LoadFile(path string) (*Policy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// categoryRule 编译后的分类规则
+type categoryRule struct {
+	cat    category.Category
+	action Action
+}
+
+// compiled 编译后的策略
+type compiled struct {
+	defaultAction Action
+	matchAction   Action
+	categories    []categoryRule
+	words         map[string]Action
+	rank          map[Action]int // 数值越小越严格
+}
+
+// compile 校验并编译策略
+func compile(p *Policy) (*compiled, error) {
+	c := &compiled{
+		defaultAction: ActionPass,
+		matchAction:   ActionBlock,
+		words:         make(map[string]Action, len(p.Words)),
+		rank:          make(map[Action]int),
+	}
+
+	precedence := p.Precedence
+	if len(precedence) == 0 {
+		precedence = defaultPrecedence
+	}
+	for i, raw := range precedence {
+		action, err := ParseAction(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := c.rank[action]; dup {
+			return nil, fmt.Errorf("%w: duplicate action %q", ErrInvalidPrecedence, action)
+		}
+		c.rank[action] = i
+	}
+	if len(c.rank) != len(defaultPrecedence) {
+		return nil, fmt.Errorf("%w: must list all of %v", ErrInvalidPrecedence, defaultPrecedence)
+	}
+
+	if p.Default != "" {
+		action, err := c.resolve(p.Default)
+		if err != nil {
+			return nil, err
+		}
+		c.defaultAction = action
+	}
+	if p.Match != "" {
+		action, err := c.resolve(p.Match)
+		if err != nil {
+			return nil, err
+		}
+		c.matchAction = action
+	}
+	for name, raw := range p.Categories {
+		cat, ok := category.ParseCategory(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownCategory, name)
+		}
+		action, err := c.resolve(raw)
+		if err != nil {
+			return nil, err
+		}
+		c.categories = append(c.categories, categoryRule{cat: cat, action: action})
+	}
+	for word, raw := range p.Words {
+		action, err := c.resolve(raw)
+		if err != nil {
+			return nil, err
+		}
+		c.words[word] = action
+	}
+	return c, nil
+}
+
+// resolve 校验动作并返回别名对应的标准动作
+func (c *compiled) resolve(raw Action) (Action, error) {
+	action, err := ParseAction(string(raw))
+	if err != nil {
+		return "", err
+	}
+	if _, ok := c.rank[action]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownAction, raw)
+	}
+	return action, nil
+}
+
+// stricter 判断 a 是否比 b 更严格
+func (c *compiled) stricter(a, b Action) bool {
+	return c.rank[a] < c.rank[b]
+}
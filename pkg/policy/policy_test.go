@@ -0,0 +1,194 @@
+package policy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// stubDetector 返回固定匹配结果的检测器
+type stubDetector struct {
+	matches []core.SensitiveWord
+}
+
+func (s *stubDetector) Detect(text string) bool { return len(s.matches) > 0 }
+func (s *stubDetector) DetectIn(text string, categories ...category.Category) bool {
+	return len(s.matches) > 0
+}
+func (s *stubDetector) Match(text string) *core.SensitiveWord { return nil }
+func (s *stubDetector) MatchIn(text string, categories ...category.Category) *core.SensitiveWord {
+	return nil
+}
+func (s *stubDetector) MatchAll(text string) []core.SensitiveWord { return s.matches }
+func (s *stubDetector) MatchAllIn(text string, categories ...category.Category) []core.SensitiveWord {
+	return s.matches
+}
+func (s *stubDetector) DetectNotIn(text string, categories ...category.Category) bool {
+	return len(s.matches) > 0
+}
+func (s *stubDetector) MatchNotIn(text string, categories ...category.Category) *core.SensitiveWord {
+	return nil
+}
+func (s *stubDetector) MatchAllNotIn(text string, categories ...category.Category) []core.SensitiveWord {
+	return s.matches
+}
+func (s *stubDetector) Score(text string) core.RiskScore { return core.RiskScore{} }
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr error
+	}{
+		{
+			name:   "合法配置",
+			config: `{"default":"pass","categories":{"gambling":"review","脏话":"mask"},"words":{"赌场":"reject"}}`,
+		},
+		{
+			name:    "未知动作",
+			config:  `{"categories":{"gambling":"delete"}}`,
+			wantErr: ErrUnknownAction,
+		},
+		{
+			name:    "未知分类",
+			config:  `{"categories":{"unknown":"block"}}`,
+			wantErr: ErrUnknownCategory,
+		},
+		{
+			name:    "优先级不完整",
+			config:  `{"precedence":["block","review"]}`,
+			wantErr: ErrInvalidPrecedence,
+		},
+		{
+			name:    "优先级重复",
+			config:  `{"precedence":["block","block","mask","pass"]}`,
+			wantErr: ErrInvalidPrecedence,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(strings.NewReader(tt.config))
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Load() error = %v, 期望 %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	policy := &Policy{
+		Categories: map[string]Action{
+			"gambling":  ActionReview,
+			"profanity": ActionMask,
+		},
+		Words: map[string]Action{
+			"老千": ActionPass,
+		},
+	}
+
+	gambling := core.SensitiveWord{Word: "赌场", Category: category.Gambling}
+	profanity := core.SensitiveWord{Word: "傻瓜", Category: category.Profanity}
+	cheat := core.SensitiveWord{Word: "老千", Category: category.Gambling}
+	metaBlock := core.SensitiveWord{Word: "博彩", Category: category.Gambling, Meta: &core.WordMeta{Action: "block"}}
+	unruled := core.SensitiveWord{Word: "骗子", Category: category.Scam}
+
+	tests := []struct {
+		name    string
+		matches []core.SensitiveWord
+		action  Action
+		reasons int
+	}{
+		{name: "无匹配", matches: nil, action: ActionPass},
+		{name: "分类规则", matches: []core.SensitiveWord{profanity}, action: ActionMask, reasons: 1},
+		{name: "取最严格动作", matches: []core.SensitiveWord{profanity, gambling}, action: ActionReview, reasons: 1},
+		{name: "词条规则优先于分类", matches: []core.SensitiveWord{cheat}, action: ActionPass, reasons: 1},
+		{name: "词库元数据动作", matches: []core.SensitiveWord{gambling, metaBlock}, action: ActionBlock, reasons: 1},
+		{name: "无规则使用匹配默认动作", matches: []core.SensitiveWord{unruled, profanity}, action: ActionBlock, reasons: 1},
+		{name: "多个同级原因", matches: []core.SensitiveWord{profanity, profanity}, action: ActionMask, reasons: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine, err := NewEngine(&stubDetector{matches: tt.matches}, policy)
+			if err != nil {
+				t.Fatalf("NewEngine() error = %v", err)
+			}
+			got := engine.Evaluate("text")
+			if got.Action != tt.action {
+				t.Errorf("Evaluate().Action = %v, 期望 %v", got.Action, tt.action)
+			}
+			if len(got.Reasons) != tt.reasons {
+				t.Errorf("Evaluate().Reasons = %v, 期望 %d 个", got.Reasons, tt.reasons)
+			}
+		})
+	}
+}
+
+func TestEngine_Precedence(t *testing.T) {
+	policy := &Policy{
+		Categories: map[string]Action{
+			"gambling":  ActionReview,
+			"profanity": ActionMask,
+		},
+		Precedence: []Action{ActionMask, ActionBlock, ActionReview, ActionPass},
+	}
+	detector := &stubDetector{matches: []core.SensitiveWord{
+		{Word: "赌场", Category: category.Gambling},
+		{Word: "傻瓜", Category: category.Profanity},
+	}}
+
+	engine, err := NewEngine(detector, policy)
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	if got := engine.Evaluate("text").Action; got != ActionMask {
+		t.Errorf("Evaluate().Action = %v, 期望 %v", got, ActionMask)
+	}
+
+	// 非法策略不应替换当前策略
+	if err := engine.SetPolicy(&Policy{Default: "drop"}); err == nil {
+		t.Fatal("SetPolicy() 期望返回错误")
+	}
+	if got := engine.Evaluate("text").Action; got != ActionMask {
+		t.Errorf("SetPolicy 失败后 Evaluate().Action = %v, 期望 %v", got, ActionMask)
+	}
+}
+
+func TestEngine_PrecedenceAliases(t *testing.T) {
+	policy := &Policy{
+		Default:    "allow",
+		Match:      "reject",
+		Categories: map[string]Action{"profanity": "log"},
+		Precedence: []Action{"reject", "MASK", "review", "allow"},
+	}
+	detector := &stubDetector{matches: []core.SensitiveWord{
+		{Word: "赌场", Category: category.Gambling},
+		{Word: "傻瓜", Category: category.Profanity},
+	}}
+
+	engine, err := NewEngine(detector, policy)
+	if err != nil {
+		t.Fatalf("NewEngine() error = %v", err)
+	}
+	if got := engine.Evaluate("text").Action; got != ActionBlock {
+		t.Errorf("Evaluate().Action = %v, 期望 %v", got, ActionBlock)
+	}
+
+	detector.matches = detector.matches[1:]
+	if got := engine.Evaluate("text").Action; got != ActionPass {
+		t.Errorf("Evaluate().Action = %v, 期望 %v", got, ActionPass)
+	}
+}
+
+func TestNewEngine_NilDetector(t *testing.T) {
+	if _, err := NewEngine(nil, nil); !errors.Is(err, ErrNoDetector) {
+		t.Errorf("NewEngine(nil) error = %v, 期望 %v", err, ErrNoDetector)
+	}
+}
@@ -0,0 +1,98 @@
+package swd
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+)
+
+// BatchResult 批量处理中单条文本的结果
+type BatchResult struct {
+	Matches []core.SensitiveWord
+	Err     error // 处理出错、panic 或因 ctx 取消未处理时不为 nil
+}
+
+// BatchFunc 批量处理中对单条文本执行的操作，需可并发调用
+type BatchFunc func(ctx context.Context, text string) ([]core.SensitiveWord, error)
+
+// BatchProcessor 以固定数量的工作协程并发处理文本，结果顺序与输入一致
+type BatchProcessor struct {
+	workers int
+}
+
+// NewBatchProcessor 创建批量处理器，workers 不大于 0 时使用 GOMAXPROCS
+func NewBatchProcessor(workers int) *BatchProcessor {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &BatchProcessor{workers: workers}
+}
+
+// Workers 返回工作协程数量
+func (p *BatchProcessor) Workers() int {
+	return p.workers
+}
+
+// Process 对每条文本执行 fn，results[i] 对应 texts[i]
+//
+// 单条文本出错（包括 panic）只记录在对应结果中，不影响其余文本。
+// ctx 取消后尚未开始的文本不再处理，其 Err 为 ctx.Err()，并返回 ctx.Err()。
+func (p *BatchProcessor) Process(ctx context.Context, texts []string, fn BatchFunc) ([]BatchResult, error) {
+	results := make([]BatchResult, len(texts))
+	workers := p.workers
+	if workers > len(texts) {
+		workers = len(texts)
+	}
+
+	var (
+		next      atomic.Int64
+		cancelled atomic.Bool
+		wg        sync.WaitGroup
+	)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(texts) {
+					return
+				}
+				if err := ctx.Err(); err != nil {
+					results[i].Err = err
+					cancelled.Store(true)
+					continue
+				}
+				results[i] = processItem(ctx, i, texts[i], fn)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if cancelled.Load() {
+		return results, ctx.Err()
+	}
+	return results, nil
+}
+
+// processItem 处理单条文本，将 panic 转换为错误
+func processItem(ctx context.Context, i int, text string, fn BatchFunc) (result BatchResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = BatchResult{Err: fmt.Errorf("%w: item %d: %v", ErrBatchItemPanic, i, r)}
+		}
+	}()
+	matches, err := fn(ctx, text)
+	return BatchResult{Matches: matches, Err: err}
+}
+
+// MatchAllBatch 使用 GOMAXPROCS 个工作协程并发返回每条文本中的所有敏感词，结果顺序与输入一致
+func (swd *SWD) MatchAllBatch(ctx context.Context, texts []string) ([]BatchResult, error) {
+	return NewBatchProcessor(0).Process(ctx, texts, func(_ context.Context, text string) ([]core.SensitiveWord, error) {
+		return swd.MatchAll(text), nil
+	})
+}
@@ -0,0 +1,184 @@
+package swd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// echo 将文本本身作为匹配结果返回，用于检查结果顺序
+func echo(_ context.Context, text string) ([]core.SensitiveWord, error) {
+	return []core.SensitiveWord{{Word: text}}, nil
+}
+
+// TestBatchProcessor_Order 测试结果顺序与输入一致
+func TestBatchProcessor_Order(t *testing.T) {
+	texts := make([]string, 1000)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("text-%d", i)
+	}
+
+	for _, workers := range []int{0, 1, 4, 2000} {
+		results, err := NewBatchProcessor(workers).Process(context.Background(), texts, echo)
+		if err != nil {
+			t.Fatalf("workers=%d: Process() 错误 = %v", workers, err)
+		}
+		if len(results) != len(texts) {
+			t.Fatalf("workers=%d: 结果数量 = %d, 期望 %d", workers, len(results), len(texts))
+		}
+		for i, result := range results {
+			if result.Err != nil || len(result.Matches) != 1 || result.Matches[0].Word != texts[i] {
+				t.Fatalf("workers=%d: 第 %d 条结果 = %+v, 期望 %q", workers, i, result, texts[i])
+			}
+		}
+	}
+
+	results, err := NewBatchProcessor(4).Process(context.Background(), nil, echo)
+	if err != nil || len(results) != 0 {
+		t.Errorf("空输入 Process() = %v, %v", results, err)
+	}
+}
+
+// TestBatchProcessor_ItemErrors 测试单条文本出错或 panic 不影响其余文本
+func TestBatchProcessor_ItemErrors(t *testing.T) {
+	errBad := errors.New("bad text")
+	fn := func(ctx context.Context, text string) ([]core.SensitiveWord, error) {
+		switch text {
+		case "error":
+			return nil, errBad
+		case "panic":
+			panic("boom")
+		}
+		return echo(ctx, text)
+	}
+
+	results, err := NewBatchProcessor(2).Process(context.Background(), []string{"a", "error", "panic", "b"}, fn)
+	if err != nil {
+		t.Fatalf("Process() 错误 = %v, 单条出错不应返回错误", err)
+	}
+	if results[0].Err != nil || results[3].Err != nil || results[3].Matches[0].Word != "b" {
+		t.Errorf("正常文本的结果 = %+v, %+v", results[0], results[3])
+	}
+	if !errors.Is(results[1].Err, errBad) {
+		t.Errorf("出错文本的错误 = %v, 期望 %v", results[1].Err, errBad)
+	}
+	if !errors.Is(results[2].Err, ErrBatchItemPanic) {
+		t.Errorf("panic 文本的错误 = %v, 期望 %v", results[2].Err, ErrBatchItemPanic)
+	}
+}
+
+// TestBatchProcessor_Cancel 测试取消后未处理的文本返回 ctx 的错误
+func TestBatchProcessor_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	texts := []string{"a", "b", "stop", "c", "d"}
+	fn := func(ctx context.Context, text string) ([]core.SensitiveWord, error) {
+		if text == "stop" {
+			cancel()
+		}
+		return echo(ctx, text)
+	}
+
+	// 单个工作协程按顺序处理，取消后的文本都不会被处理
+	results, err := NewBatchProcessor(1).Process(ctx, texts, fn)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Process() 错误 = %v, 期望 %v", err, context.Canceled)
+	}
+	for i, result := range results {
+		processed := i <= 2
+		if processed && (result.Err != nil || result.Matches[0].Word != texts[i]) {
+			t.Errorf("第 %d 条结果 = %+v, 期望已处理", i, result)
+		}
+		if !processed && !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("第 %d 条结果的错误 = %v, 期望 %v", i, result.Err, context.Canceled)
+		}
+	}
+}
+
+// TestSWD_MatchAllBatch 测试批量匹配与逐条匹配结果一致
+func TestSWD_MatchAllBatch(t *testing.T) {
+	swd, err := New(NewDefaultFactory())
+	if err != nil {
+		t.Fatalf("创建实例失败: %v", err)
+	}
+	_ = swd.Clear()
+	if err := swd.AddWords(map[string]category.Category{
+		"赌场": category.Gambling,
+		"傻瓜": category.Profanity,
+	}); err != nil {
+		t.Fatalf("添加词条失败: %v", err)
+	}
+
+	texts := []string{"去赌场吗", "你好", "", "傻瓜去赌场", "傻瓜"}
+	results, err := swd.MatchAllBatch(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("MatchAllBatch() 错误 = %v", err)
+	}
+	for i, text := range texts {
+		if want := swd.MatchAll(text); !reflect.DeepEqual(results[i].Matches, want) {
+			t.Errorf("第 %d 条结果 = %v, 期望 %v", i, results[i].Matches, want)
+		}
+	}
+}
+
+var (
+	benchOnce   sync.Once
+	benchEngine *SWD
+	benchTexts  []string
+)
+
+// benchSetup 使用内置词库创建共享的引擎和测试文本
+func benchSetup(b *testing.B) (*SWD, []string) {
+	b.Helper()
+	benchOnce.Do(func() {
+		engine, err := New(NewDefaultFactory())
+		if err != nil {
+			b.Fatalf("创建实例失败: %v", err)
+		}
+		benchEngine = engine
+
+		samples := []string{
+			"今天天气不错，我们一起去公园散步吧",
+			"有人在群里约赌钱，请注意甄别",
+			"产品评论：质量很好，物流也很快，下次还会购买",
+			"你这个混蛋，别再发一夜情的广告了",
+		}
+		benchTexts = make([]string, 1000)
+		for i := range benchTexts {
+			benchTexts[i] = strings.Repeat(samples[i%len(samples)], 1+i%5)
+		}
+	})
+	return benchEngine, benchTexts
+}
+
+// BenchmarkMatchAll_Sequential 性能测试 - 逐条调用 MatchAll 作为对照
+func BenchmarkMatchAll_Sequential(b *testing.B) {
+	engine, texts := benchSetup(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, text := range texts {
+			_ = engine.MatchAll(text)
+		}
+	}
+	b.ReportMetric(float64(b.N*len(texts))/b.Elapsed().Seconds(), "texts/s")
+}
+
+// BenchmarkMatchAllBatch 性能测试 - 批量并发匹配
+func BenchmarkMatchAllBatch(b *testing.B) {
+	engine, texts := benchSetup(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := engine.MatchAllBatch(context.Background(), texts); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.N*len(texts))/b.Elapsed().Seconds(), "texts/s")
+}
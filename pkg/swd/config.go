@@ -0,0 +1,270 @@
+package swd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/ttofTnT/go-swd/pkg/algorithm"
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/detector"
+	"github.com/ttofTnT/go-swd/pkg/detector/preprocessor"
+	"github.com/ttofTnT/go-swd/pkg/dictionary"
+	"github.com/ttofTnT/go-swd/pkg/filter"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// Profile 预置的检测配置
+type Profile string
+
+const (
+	ProfileExact   Profile = "exact"   // 精确匹配，不做任何预处理
+	ProfileDefault Profile = "default" // 忽略大小写和全半角差异，删除不可见字符
+	ProfileStrict  Profile = "strict"  // 另外按 NFKC 折叠字符，忽略数字样式差异、空白字符、emoji 和 leetspeak 替换
+)
+
+// profiles 预置配置对应的检测选项
+var profiles = map[Profile]core.SWDOptions{
+	ProfileExact:   {},
+	ProfileDefault: {IgnoreCase: true, IgnoreWidth: true, StripInvisible: true},
+	ProfileStrict: {
+		IgnoreCase: true, IgnoreWidth: true, IgnoreNumStyle: true, SkipWhitespace: true,
+		StripInvisible: true, NormalizeUnicode: true, StripEmoji: true, EnableLeetspeak: true,
+	},
+}
+
+// ProfileOptions 返回预置配置对应的检测选项，未知配置返回 ErrUnknownProfile
+func ProfileOptions(profile Profile) (core.SWDOptions, error) {
+	options, ok := profiles[profile]
+	if !ok {
+		return core.SWDOptions{}, fmt.Errorf("%w: %q", ErrUnknownProfile, profile)
+	}
+	return options, nil
+}
+
+// config NewWithOptions 的配置
+type config struct {
+	profile     Profile
+	algorithm   core.AlgorithmType
+	words       map[string]category.Category
+	meta        map[string]core.WordMeta
+	allowlist   []string
+	normalizers []core.Normalizer
+	pipeline    []func(*preprocessor.Pipeline) error
+	subs        core.Substitutions
+	logger      *slog.Logger
+	inst        core.Instrumentation
+}
+
+// Option NewWithOptions 的配置项
+type Option func(*config)
+
+// WithProfile 设置预置的检测配置，默认为 ProfileExact
+func WithProfile(profile Profile) Option {
+	return func(c *config) {
+		c.profile = profile
+	}
+}
+
+// WithAlgorithm 设置匹配算法，默认为 aho-corasick
+func WithAlgorithm(algo core.AlgorithmType) Option {
+	return func(c *config) {
+		c.algorithm = algo
+	}
+}
+
+// WithWords 在默认词库之外添加词条，可多次使用
+func WithWords(words map[string]category.Category) Option {
+	return func(c *config) {
+		if c.words == nil {
+			c.words = make(map[string]category.Category, len(words))
+		}
+		for word, cat := range words {
+			c.words[word] = cat
+		}
+	}
+}
+
+// WithWordMeta 在默认词库之外添加带元数据的词条，可多次使用
+func WithWordMeta(word string, cat category.Category, meta core.WordMeta) Option {
+	return func(c *config) {
+		WithWords(map[string]category.Category{word: cat})(c)
+		if c.meta == nil {
+			c.meta = make(map[string]core.WordMeta)
+		}
+		c.meta[word] = meta
+	}
+}
+
+// WithAllowlist 添加白名单词条，落在其出现范围内的匹配会被忽略，可多次使用
+func WithAllowlist(words ...string) Option {
+	return func(c *config) {
+		c.allowlist = append(c.allowlist, words...)
+	}
+}
+
+// WithPreprocessors 添加在内置预处理之后依次执行的自定义标准化阶段，可多次使用
+func WithPreprocessors(normalizers ...core.Normalizer) Option {
+	return func(c *config) {
+		c.normalizers = append(c.normalizers, normalizers...)
+	}
+}
+
+// WithPipeline 编辑预处理流水线，可插入、移除内置阶段或调整顺序，可多次使用
+//
+// 流水线初始为预置配置启用的内置阶段加上 WithPreprocessors 添加的阶段（依次命名为 custom-1、custom-2 等），
+// edit 返回的错误以 ErrInvalidOption 包装后由 NewWithOptions 返回。
+func WithPipeline(edit func(p *preprocessor.Pipeline) error) Option {
+	return func(c *config) {
+		c.pipeline = append(c.pipeline, edit)
+	}
+}
+
+// WithSubstitutions 添加替换字符表，匹配任一候选字符组合构成的词条，可多次使用
+//
+// 替换在预处理之后生效，与预置配置启用的内置 leetspeak 表合并，同名字符以后添加的为准。
+// 内置表可由 preprocessor.Leetspeak() 获得，用于在不启用 strict 配置时单独开启。
+func WithSubstitutions(subs core.Substitutions) Option {
+	return func(c *config) {
+		if c.subs == nil {
+			c.subs = make(core.Substitutions, len(subs))
+		}
+		for r, candidates := range subs {
+			c.subs[r] = candidates
+		}
+	}
+}
+
+// WithLogger 设置结构化日志记录器，默认使用 slog.Default()
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithMetrics 设置指标采集，如 metrics.NewPrometheus 的返回值
+func WithMetrics(inst core.Instrumentation) Option {
+	return func(c *config) {
+		c.inst = inst
+	}
+}
+
+// NewWithOptions 按配置项创建敏感词检测引擎
+//
+// 所有配置项先经过校验，再一次性加载默认词库和 WithWords 添加的词条并构建匹配算法，
+// 配置无效时返回 ErrUnknownProfile、ErrUnsupportedAlgorithm 或 ErrInvalidOption。
+func NewWithOptions(opts ...Option) (*SWD, error) {
+	c := &config{profile: ProfileExact}
+	for _, opt := range opts {
+		opt(c)
+	}
+	options, err := c.options()
+	if err != nil {
+		return nil, err
+	}
+
+	swd, err := NewFromFactory(&configFactory{options: options, words: c.words, meta: c.meta})
+	if err != nil {
+		return nil, err
+	}
+	if c.inst != nil {
+		if err := swd.SetInstrumentation(c.inst); err != nil {
+			return nil, err
+		}
+	}
+	return swd, nil
+}
+
+// options 校验配置并生成检测选项
+func (c *config) options() (core.SWDOptions, error) {
+	options, err := ProfileOptions(c.profile)
+	if err != nil {
+		return core.SWDOptions{}, err
+	}
+	if _, err := algorithm.New(c.algorithm); err != nil {
+		return core.SWDOptions{}, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, c.algorithm)
+	}
+	for word := range c.words {
+		if word == "" {
+			return core.SWDOptions{}, fmt.Errorf("%w: empty word", ErrInvalidOption)
+		}
+	}
+	for _, word := range c.allowlist {
+		if word == "" {
+			return core.SWDOptions{}, fmt.Errorf("%w: empty allowlist word", ErrInvalidOption)
+		}
+	}
+	for _, n := range c.normalizers {
+		if n == nil {
+			return core.SWDOptions{}, fmt.Errorf("%w: %w", ErrInvalidOption, ErrNoNormalizer)
+		}
+	}
+
+	for r, candidates := range c.subs {
+		if len(candidates) == 0 {
+			return core.SWDOptions{}, fmt.Errorf("%w: no candidates for substitution %q", ErrInvalidOption, r)
+		}
+	}
+
+	options.Algorithm = c.algorithm
+	options.Allowlist = c.allowlist
+	options.Normalizers = c.normalizers
+	options.Substitutions = c.subs
+	options.Logger = c.logger
+
+	if len(c.pipeline) > 0 {
+		pipeline := preprocessor.DefaultPipeline(options)
+		for _, edit := range c.pipeline {
+			if edit == nil {
+				continue
+			}
+			if err := edit(pipeline); err != nil {
+				return core.SWDOptions{}, fmt.Errorf("%w: %w", ErrInvalidOption, err)
+			}
+		}
+		options.Stages = pipeline.Stages()
+	}
+	return options, nil
+}
+
+// configFactory 以校验后的检测选项创建组件，词库加载完成后才构建匹配算法
+type configFactory struct {
+	options core.SWDOptions
+	words   map[string]category.Category
+	meta    map[string]core.WordMeta
+}
+
+// BuildComponents 实现 Factory 接口
+func (f *configFactory) BuildComponents(options *core.SWDOptions) (core.Detector, core.Filter, core.Loader, error) {
+	*options = f.options
+
+	loader := dictionary.NewLoader()
+	if options.Logger != nil {
+		loader.SetLogger(options.Logger)
+	}
+	if err := loader.LoadDefaultWords(context.Background()); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %w", ErrDictionaryLoad, err)
+	}
+	if len(f.words) > 0 {
+		if err := loader.AddWords(f.words); err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: %w", ErrInvalidOption, err)
+		}
+	}
+	for word, meta := range f.meta {
+		if err := loader.AddWordWithMeta(word, f.words[word], meta); err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: %w", ErrInvalidOption, err)
+		}
+	}
+
+	// 以完整快照初始化检测器，多分类和元数据在注册观察者之前同步
+	snapshot := loader.Snapshot()
+	d, err := detector.NewDetectorWithWords(*options, snapshot.Words, loader.Version())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %w", ErrDetectorCreate, err)
+	}
+	d.(core.MetaObserver).OnMetaChanged(snapshot.Meta)
+	d.(core.CategoriesObserver).OnCategoriesChanged(snapshot.Categories)
+	loader.AddObserver(d.(core.Observer))
+
+	return d, filter.NewFilter(d), loader, nil
+}
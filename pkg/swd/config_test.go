@@ -0,0 +1,260 @@
+package swd
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/detector/preprocessor"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// TestNewWithOptions 测试配置项在构建匹配算法之前生效
+func TestNewWithOptions(t *testing.T) {
+	var buf bytes.Buffer
+	r := &recorder{}
+	dropStars := core.NormalizerFunc(func(dst []rune, c rune) []rune {
+		if c == '*' {
+			return dst
+		}
+		return append(dst, c)
+	})
+
+	swd, err := NewWithOptions(
+		WithProfile(ProfileDefault),
+		WithAlgorithm(core.AlgorithmTrie),
+		WithWords(map[string]category.Category{"赌场": category.Gambling, "fool": category.Profanity}),
+		WithAllowlist("赌场风云"),
+		WithPreprocessors(dropStars),
+		WithLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		WithMetrics(r),
+	)
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want bool
+	}{
+		{"added word", "去赌场", true},
+		{"default dictionary", "赌钱", true},
+		{"profile ignores case and width", "ＦＯＯＬ", true},
+		{"custom preprocessor", "赌*场", true},
+		{"allowlisted range", "看赌场风云", false},
+		{"outside allowlisted range", "赌场风云里的赌场", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := swd.Detect(tt.text); got != tt.want {
+				t.Errorf("Detect(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+	if matches := swd.MatchAll("赌场风云里的赌场"); len(matches) != 1 || matches[0].StartPos != 6 {
+		t.Errorf("MatchAll() = %+v, want only the match outside the allowlist", matches)
+	}
+
+	if swd.options.Algorithm != core.AlgorithmTrie || !swd.options.IgnoreCase {
+		t.Errorf("options = %+v, want trie with default profile", swd.options)
+	}
+	if r.words == 0 || len(r.ops) == 0 {
+		t.Errorf("recorder words = %d, ops = %v, want metrics to be collected", r.words, r.ops)
+	}
+	if err := swd.AddWord("老千", category.Gambling); err != nil {
+		t.Fatalf("AddWord() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `msg="rebuilt matcher" algorithm=trie`) {
+		t.Errorf("logs = %q, want rebuild logged with the configured logger", buf.String())
+	}
+}
+
+// TestNewWithOptions_Snapshot 测试创建后立即可用次要分类和元数据，无需等待词库变更通知
+func TestNewWithOptions_Snapshot(t *testing.T) {
+	// 赌钱 在默认词库中属于赌博，再以诈骗添加后同时属于两个分类
+	swd, err := NewWithOptions(
+		WithWords(map[string]category.Category{"赌钱": category.Scam}),
+		WithWordMeta("老千", category.Gambling, core.WordMeta{Severity: 3, Action: "block"}),
+	)
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+
+	if !swd.DetectIn("去赌钱", category.Scam) {
+		t.Error("DetectIn(Scam) = false, want secondary category to match right after New")
+	}
+	matches := swd.MatchAll("老千")
+	if len(matches) != 1 || matches[0].Meta == nil || matches[0].Meta.Severity != 3 {
+		t.Fatalf("MatchAll() = %+v, want meta with severity 3", matches)
+	}
+	if matches[0].Meta.Action != "block" {
+		t.Errorf("Meta.Action = %q, want block", matches[0].Meta.Action)
+	}
+}
+
+// TestNewWithOptions_Invalid 测试无效配置在加载词库之前返回哨兵错误
+func TestNewWithOptions_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want error
+	}{
+		{"unknown profile", []Option{WithProfile("paranoid")}, ErrUnknownProfile},
+		{"unsupported algorithm", []Option{WithAlgorithm(core.AlgorithmDFA)}, ErrUnsupportedAlgorithm},
+		{"empty word", []Option{WithWords(map[string]category.Category{"": category.Gambling})}, ErrInvalidOption},
+		{"empty allowlist word", []Option{WithAllowlist("")}, ErrInvalidOption},
+		{"nil preprocessor", []Option{WithPreprocessors(nil)}, ErrNoNormalizer},
+		{"invalid pipeline edit", []Option{WithPipeline(func(p *preprocessor.Pipeline) error {
+			return p.Remove(preprocessor.StageEmoji)
+		})}, preprocessor.ErrStageNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			swd, err := NewWithOptions(tt.opts...)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("NewWithOptions() error = %v, want %v", err, tt.want)
+			}
+			if swd != nil {
+				t.Error("NewWithOptions() returned an instance for invalid options")
+			}
+		})
+	}
+}
+
+// TestNewWithOptions_Pipeline 测试编辑预处理流水线
+func TestNewWithOptions_Pipeline(t *testing.T) {
+	swd, err := NewWithOptions(
+		WithWords(map[string]category.Category{"赌场": category.Gambling}),
+		WithPipeline(func(p *preprocessor.Pipeline) error {
+			for _, name := range []string{preprocessor.StageInvisible, preprocessor.StageTraditional} {
+				stage, err := preprocessor.NewStage(name)
+				if err != nil {
+					return err
+				}
+				if err := p.Append(stage); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+	if want := []string{preprocessor.StageInvisible, preprocessor.StageTraditional}; len(swd.options.Stages) != len(want) {
+		t.Errorf("Stages = %v, want %v", swd.options.Stages, want)
+	}
+	if !swd.Detect("去賭\u200b場") {
+		t.Error("Detect() = false, want the pipeline to fold traditional characters and zero-width spaces")
+	}
+}
+
+// TestNewWithOptions_Unicode 测试严格配置折叠兼容字符并删除不可见字符
+func TestNewWithOptions_Unicode(t *testing.T) {
+	swd, err := NewWithOptions(
+		WithProfile(ProfileStrict),
+		WithWords(map[string]category.Category{"赌博": category.Gambling, "fuck": category.Profanity}),
+	)
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+
+	for _, text := range []string{
+		"赌\u200b博",
+		"赌\u00ad博\u202e",
+		"ｆｕｃｋ",
+		"\U0001d41f\U0001d42e\U0001d41c\U0001d424",
+		"Ⓕⓤⓒⓚ",
+		"f\u0301u\u0308ck",
+	} {
+		if !swd.Detect(text) {
+			t.Errorf("Detect(%q) = false, want true", text)
+		}
+	}
+}
+
+// TestNewWithOptions_ProfileReplace 测试各预置配置下替换只作用于敏感词在原文中的字符
+func TestNewWithOptions_ProfileReplace(t *testing.T) {
+	tests := []struct {
+		profile Profile
+		text    string
+		want    string
+	}{
+		{ProfileExact, "a testword b", "a ******** b"},
+		{ProfileExact, "a test\u200bword b", "a test\u200bword b"},
+		{ProfileDefault, "a TESTWORD b", "a ******** b"},
+		{ProfileDefault, "a ｔｅｓｔword b", "a ******** b"},
+		{ProfileDefault, "a\u200btestword tail", "a\u200b******** tail"},
+		{ProfileDefault, "a test\u200bword tail", "a ********* tail"},
+		{ProfileStrict, "a test\u200bword tail", "a ********* tail"},
+		{ProfileStrict, "a t3stw0rd tail", "a ******** tail"},
+		{ProfileStrict, "x t e s t w o r d!", "x ***************!"},
+		{ProfileStrict, "a ﬁne day", "a *** day"},
+		{ProfileStrict, "a 😀testword😀 b", "a 😀********😀 b"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.profile)+"/"+tt.text, func(t *testing.T) {
+			swd, err := NewWithOptions(
+				WithProfile(tt.profile),
+				WithWords(map[string]category.Category{"testword": category.Profanity, "fine": category.Profanity}),
+			)
+			if err != nil {
+				t.Fatalf("NewWithOptions() error = %v", err)
+			}
+			if got := swd.ReplaceWithAsterisk(tt.text); got != tt.want {
+				t.Errorf("ReplaceWithAsterisk(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewWithOptions_Substitutions 测试 leetspeak 和符号替换
+func TestNewWithOptions_Substitutions(t *testing.T) {
+	words := WithWords(map[string]category.Category{
+		"fuck": category.Profanity,
+		"shit": category.Profanity,
+		"ass":  category.Profanity,
+		"sex":  category.Pornography,
+	})
+	strict, err := NewWithOptions(WithProfile(ProfileStrict), words)
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+	for _, text := range []string{"$hit", "sh1t", "@ss", "a$$", "5ex", "ＦＵ(Ｋ"} {
+		if !strict.Detect(text) {
+			t.Errorf("Detect(%q) = false, want true", text)
+		}
+	}
+
+	custom, err := NewWithOptions(
+		WithProfile(ProfileDefault),
+		WithAlgorithm(core.AlgorithmTrie),
+		WithSubstitutions(core.Substitutions{'v': {'u'}}),
+		words,
+	)
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+	if !custom.Detect("fvck") || custom.Detect("$hit") {
+		t.Error("custom substitutions should replace the built-in leetspeak table outside the strict profile")
+	}
+
+	if _, err := NewWithOptions(WithSubstitutions(core.Substitutions{'4': nil})); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("NewWithOptions() error = %v, want %v", err, ErrInvalidOption)
+	}
+}
+
+// TestProfileOptions 测试预置检测配置
+func TestProfileOptions(t *testing.T) {
+	options, err := ProfileOptions(ProfileStrict)
+	if err != nil || !options.SkipWhitespace || !options.IgnoreNumStyle {
+		t.Errorf("ProfileOptions(strict) = %+v, %v", options, err)
+	}
+	if _, err := ProfileOptions("loose"); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("ProfileOptions(loose) error = %v, want %v", err, ErrUnknownProfile)
+	}
+}
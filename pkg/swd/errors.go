@@ -11,6 +11,36 @@ var (
 	ErrNoFilter = errors.New("no filter provided")
 	// ErrNoLoader 没有提供加载器
 	ErrNoLoader = errors.New("no loader provided")
+	// ErrDictionaryLoad 工厂加载默认词库失败
+	ErrDictionaryLoad = errors.New("failed to load default dictionary")
+	// ErrDetectorCreate 工厂创建检测器失败
+	ErrDetectorCreate = errors.New("failed to create detector")
+	// ErrFactoryPanic 适配的 ComponentFactory 在创建组件时 panic
+	ErrFactoryPanic = errors.New("component factory panicked")
+	// ErrUnknownProfile 未知的预置检测配置
+	ErrUnknownProfile = errors.New("unknown profile")
+	// ErrUnsupportedAlgorithm 不支持的匹配算法
+	ErrUnsupportedAlgorithm = errors.New("unsupported algorithm")
+	// ErrInvalidOption 配置项无效
+	ErrInvalidOption = errors.New("invalid option")
 	// ErrNoNormalizer 没有提供文本标准化处理器
 	ErrNoNormalizer = errors.New("no normalizer provided")
+	// ErrWatchUnsupported 加载器不支持文件热加载
+	ErrWatchUnsupported = errors.New("loader does not support file watching")
+	// ErrSourceUnsupported 加载器不支持远程词库来源
+	ErrSourceUnsupported = errors.New("loader does not support word sources")
+	// ErrVersionUnsupported 加载器不支持词库版本管理
+	ErrVersionUnsupported = errors.New("loader does not support versioning")
+	// ErrToggleUnsupported 检测器不支持启用、停用分类
+	ErrToggleUnsupported = errors.New("detector does not support category toggles")
+	// ErrProvenanceUnsupported 加载器不支持词条来源追踪
+	ErrProvenanceUnsupported = errors.New("loader does not support provenance")
+	// ErrReportUnsupported 检测器不支持生成检测报告
+	ErrReportUnsupported = errors.New("detector does not support reports")
+	// ErrRegistryUnsupported 加载器不支持独立的分类注册表
+	ErrRegistryUnsupported = errors.New("loader does not support category registries")
+	// ErrInstrumentationUnsupported 检测器、过滤器和加载器均不支持指标采集
+	ErrInstrumentationUnsupported = errors.New("components do not support instrumentation")
+	// ErrBatchItemPanic 批量处理中单条文本的处理函数发生 panic
+	ErrBatchItemPanic = errors.New("batch item panicked")
 )
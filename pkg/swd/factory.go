@@ -10,21 +10,44 @@ import (
 	"github.com/ttofTnT/go-swd/pkg/filter"
 )
 
-// DefaultFactory 默认组件工厂实现
+// Factory 创建组件时可返回错误的工厂接口，由 NewFromFactory 使用
+type Factory interface {
+	// BuildComponents 创建并关联所有组件，失败时返回错误而不是 panic
+	BuildComponents(options *core.SWDOptions) (core.Detector, core.Filter, core.Loader, error)
+}
+
+// DefaultFactory 默认组件工厂实现，同时实现 Factory 和 ComponentFactory
 type DefaultFactory struct{}
 
+// 编译期检查
+var (
+	_ Factory          = (*DefaultFactory)(nil)
+	_ ComponentFactory = (*DefaultFactory)(nil)
+)
+
 // NewDefaultFactory 创建默认工厂实例
-func NewDefaultFactory() ComponentFactory {
+func NewDefaultFactory() *DefaultFactory {
 	return &DefaultFactory{}
 }
 
-// CreateDetector 创建检测器实例
+// BuildDetector 创建检测器实例
+func (f *DefaultFactory) BuildDetector(options *core.SWDOptions) (core.Detector, error) {
+	d, err := detector.NewDetector(*options)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDetectorCreate, err)
+	}
+	return d, nil
+}
+
+// CreateDetector 创建检测器实例，失败时以包装了 ErrDetectorCreate 的错误 panic
+//
+// Deprecated: 使用 BuildDetector
 func (f *DefaultFactory) CreateDetector(options *core.SWDOptions) core.Detector {
-	detector, err := detector.NewDetector(*options)
+	d, err := f.BuildDetector(options)
 	if err != nil {
-		panic(fmt.Sprintf("创建检测器失败: %v", err))
+		panic(err)
 	}
-	return detector
+	return d
 }
 
 // CreateFilter 创建过滤器实例
@@ -38,26 +61,81 @@ func (f *DefaultFactory) CreateLoader() core.Loader {
 	return loader
 }
 
-// CreateComponents 创建并关联所有组件
-func (f *DefaultFactory) CreateComponents(options *core.SWDOptions) (core.Detector, core.Filter, core.Loader) {
+// BuildComponents 实现 Factory 接口，默认词库加载失败时返回包装了 ErrDictionaryLoad 的错误
+func (f *DefaultFactory) BuildComponents(options *core.SWDOptions) (core.Detector, core.Filter, core.Loader, error) {
 	// 创建加载器
 	loader := f.CreateLoader()
+	if setter, ok := loader.(core.LoggerSetter); ok && options.Logger != nil {
+		setter.SetLogger(options.Logger)
+	}
 
 	// 加载默认词库
 	if err := loader.LoadDefaultWords(context.Background()); err != nil {
-		panic(fmt.Sprintf("加载默认词库失败: %v", err))
+		return nil, nil, nil, fmt.Errorf("%w: %w", ErrDictionaryLoad, err)
 	}
 
 	// 创建检测器
-	d := f.CreateDetector(options)
+	d, err := f.BuildDetector(options)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	// 注册检测器为加载器的观察者
 	if observer, ok := d.(core.Observer); ok {
-		loader.(*dictionary.Loader).AddObserver(observer)
+		if observable, ok := loader.(interface{ AddObserver(core.Observer) }); ok {
+			observable.AddObserver(observer)
+		}
 	}
 
 	// 创建过滤器
 	filter := f.CreateFilter(d)
 
+	return d, filter, loader, nil
+}
+
+// CreateComponents 创建并关联所有组件，失败时以 BuildComponents 返回的错误 panic
+//
+// Deprecated: 使用 BuildComponents
+func (f *DefaultFactory) CreateComponents(options *core.SWDOptions) (core.Detector, core.Filter, core.Loader) {
+	d, filter, loader, err := f.BuildComponents(options)
+	if err != nil {
+		panic(err)
+	}
 	return d, filter, loader
 }
+
+// legacyFactory 将 ComponentFactory 适配为 Factory，把创建过程中的 panic 转换为错误
+type legacyFactory struct {
+	factory ComponentFactory
+}
+
+// AdaptFactory 将只实现 ComponentFactory 的自定义工厂适配为 Factory
+//
+// 工厂 panic 时，若 panic 的值是 error 则原样返回（DefaultFactory 的旧方法 panic 的错误包装了
+// ErrDictionaryLoad 等哨兵错误），否则返回包装了 ErrFactoryPanic 的错误。
+func AdaptFactory(factory ComponentFactory) Factory {
+	if factory == nil {
+		return nil
+	}
+	// 只有 DefaultFactory 本身可以直接使用，嵌入它的工厂可能重写了 CreateComponents
+	if f, ok := factory.(*DefaultFactory); ok {
+		return f
+	}
+	return legacyFactory{factory: factory}
+}
+
+// BuildComponents 实现 Factory 接口
+func (f legacyFactory) BuildComponents(options *core.SWDOptions) (d core.Detector, filter core.Filter, loader core.Loader, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%w: %v", ErrFactoryPanic, r)
+			}
+			d, filter, loader = nil, nil, nil
+		}
+	}()
+	d, filter, loader = f.factory.CreateComponents(options)
+	return d, filter, loader, nil
+}
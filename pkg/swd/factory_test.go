@@ -0,0 +1,80 @@
+package swd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+)
+
+// panicFactory 创建组件时以指定的值 panic 的旧式工厂
+type panicFactory struct {
+	DefaultFactory
+	value interface{}
+}
+
+func (f *panicFactory) CreateComponents(*core.SWDOptions) (core.Detector, core.Filter, core.Loader) {
+	panic(f.value)
+}
+
+// nilFactory 不创建检测器的旧式工厂
+type nilFactory struct {
+	DefaultFactory
+}
+
+func (f *nilFactory) CreateComponents(*core.SWDOptions) (core.Detector, core.Filter, core.Loader) {
+	return nil, nil, nil
+}
+
+// errFactory 返回指定错误的工厂
+type errFactory struct {
+	err error
+}
+
+func (f errFactory) BuildComponents(*core.SWDOptions) (core.Detector, core.Filter, core.Loader, error) {
+	return nil, nil, nil, f.err
+}
+
+// TestNew_FactoryErrors 测试工厂的错误和 panic 作为哨兵错误返回
+func TestNew_FactoryErrors(t *testing.T) {
+	loadErr := fmt.Errorf("%w: %w", ErrDictionaryLoad, errors.New("disk on fire"))
+	tests := []struct {
+		name string
+		new  func() (*SWD, error)
+		want error
+	}{
+		{"nil Factory", func() (*SWD, error) { return NewFromFactory(nil) }, ErrNoFactory},
+		{"Factory 返回错误", func() (*SWD, error) { return NewFromFactory(errFactory{err: loadErr}) }, ErrDictionaryLoad},
+		{"旧式工厂 panic 字符串", func() (*SWD, error) { return New(&panicFactory{value: "boom"}) }, ErrFactoryPanic},
+		{"旧式工厂 panic 错误", func() (*SWD, error) { return New(&panicFactory{value: loadErr}) }, ErrDictionaryLoad},
+		{"旧式工厂未创建检测器", func() (*SWD, error) { return New(&nilFactory{}) }, ErrNoDetector},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.new()
+			if !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+			if got != nil {
+				t.Errorf("got = %v, want nil", got)
+			}
+		})
+	}
+}
+
+// TestAdaptFactory 测试只有 DefaultFactory 本身直接使用，嵌入它的旧式工厂仍调用重写的 CreateComponents
+func TestAdaptFactory(t *testing.T) {
+	factory := NewDefaultFactory()
+	if adapted := AdaptFactory(factory); adapted != Factory(factory) {
+		t.Errorf("AdaptFactory(DefaultFactory) = %T, want the factory itself", adapted)
+	}
+	if adapted := AdaptFactory(&nilFactory{}); adapted == nil {
+		t.Fatal("AdaptFactory() returned nil")
+	} else if _, _, _, err := adapted.BuildComponents(&core.SWDOptions{}); err != nil {
+		t.Errorf("BuildComponents() error = %v, want overridden CreateComponents to be used", err)
+	}
+	if AdaptFactory(nil) != nil {
+		t.Error("AdaptFactory(nil) should return nil")
+	}
+}
@@ -2,13 +2,20 @@ package swd
 
 import (
 	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/ttofTnT/go-swd/pkg/types/category"
 
 	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/dictionary"
+	"github.com/ttofTnT/go-swd/pkg/tenant"
 )
 
-// ComponentFactory 定义了创建各种组件的工厂接口
+// ComponentFactory 定义了创建各种组件的工厂接口，无法返回错误，新的工厂应实现 Factory
 type ComponentFactory interface {
 	CreateDetector(options *core.SWDOptions) core.Detector
 	CreateFilter(detector core.Detector) core.Filter
@@ -24,16 +31,29 @@ type SWD struct {
 	options  *core.SWDOptions
 }
 
-// New 创建一个敏感词检测引擎
+// New 使用 ComponentFactory 创建一个敏感词检测引擎，工厂经 AdaptFactory 适配，panic 会作为错误返回
+//
+// 按配置项创建请使用 NewWithOptions；根包的 swd.New 对应 NewWithOptions，swd.NewWithFactory 对应本函数。
 func New(factory ComponentFactory) (*SWD, error) {
 	if factory == nil {
 		return nil, ErrNoFactory
 	}
+	return NewFromFactory(AdaptFactory(factory))
+}
+
+// NewFromFactory 使用 Factory 创建一个敏感词检测引擎，返回工厂创建组件时的错误
+func NewFromFactory(factory Factory) (*SWD, error) {
+	if factory == nil {
+		return nil, ErrNoFactory
+	}
 
 	options := &core.SWDOptions{}
 
-	// 使用工厂的CreateComponents方法创建并关联组件
-	detector, filter, loader := factory.CreateComponents(options)
+	// 使用工厂的BuildComponents方法创建并关联组件
+	detector, filter, loader, err := factory.BuildComponents(options)
+	if err != nil {
+		return nil, err
+	}
 
 	if detector == nil {
 		return nil, ErrNoDetector
@@ -63,6 +83,145 @@ func (swd *SWD) LoadCustomWords(ctx context.Context, words map[string]category.C
 	return swd.loader.LoadCustomWords(ctx, words)
 }
 
+// LoadFrom 按指定格式从 Reader 加载词库
+func (swd *SWD) LoadFrom(ctx context.Context, r io.Reader, format core.DictFormat) error {
+	return swd.loader.LoadFrom(ctx, r, format)
+}
+
+// LoadFile 加载词库文件，格式由扩展名决定
+func (swd *SWD) LoadFile(ctx context.Context, path string) error {
+	return swd.loader.LoadFile(ctx, path)
+}
+
+// Export 按指定格式导出当前词库
+func (swd *SWD) Export(w io.Writer, format core.DictFormat) error {
+	return swd.loader.Export(w, format)
+}
+
+// NewWatcher 创建监听词库文件的热加载器，需调用 Run 开始监听
+func (swd *SWD) NewWatcher(paths []string, opts ...dictionary.WatcherOption) (*dictionary.Watcher, error) {
+	loader, ok := swd.loader.(*dictionary.Loader)
+	if !ok {
+		return nil, ErrWatchUnsupported
+	}
+	return dictionary.NewWatcher(loader, paths, opts...), nil
+}
+
+// Sync 从远程词库来源同步一次，失败时保留上一份成功的快照
+func (swd *SWD) Sync(ctx context.Context, src core.WordSource) error {
+	loader, ok := swd.loader.(*dictionary.Loader)
+	if !ok {
+		return ErrSourceUnsupported
+	}
+	return loader.Sync(ctx, src)
+}
+
+// Poll 按固定间隔同步远程词库来源，直到 ctx 结束
+func (swd *SWD) Poll(ctx context.Context, src core.WordSource, interval time.Duration, onError func(error)) error {
+	loader, ok := swd.loader.(*dictionary.Loader)
+	if !ok {
+		return ErrSourceUnsupported
+	}
+	return loader.Poll(ctx, src, interval, onError)
+}
+
+// Version 返回当前词库版本
+func (swd *SWD) Version() (uint64, error) {
+	loader, ok := swd.loader.(*dictionary.Loader)
+	if !ok {
+		return 0, ErrVersionUnsupported
+	}
+	return loader.Version(), nil
+}
+
+// Changelog 返回指定版本之后的词库变更记录
+func (swd *SWD) Changelog(since uint64) ([]dictionary.Change, error) {
+	loader, ok := swd.loader.(*dictionary.Loader)
+	if !ok {
+		return nil, ErrVersionUnsupported
+	}
+	return loader.Changelog(since), nil
+}
+
+// Diff 比较两个词库版本
+func (swd *SWD) Diff(from, to uint64) (dictionary.Diff, error) {
+	loader, ok := swd.loader.(*dictionary.Loader)
+	if !ok {
+		return dictionary.Diff{}, ErrVersionUnsupported
+	}
+	return loader.Diff(from, to)
+}
+
+// Rollback 将词库恢复到指定版本
+func (swd *SWD) Rollback(version uint64) error {
+	loader, ok := swd.loader.(*dictionary.Loader)
+	if !ok {
+		return ErrVersionUnsupported
+	}
+	return loader.Rollback(version)
+}
+
+// NewTenantManager 创建以当前检测器为共享基础词库的租户管理器
+func (swd *SWD) NewTenantManager() (*tenant.Manager, error) {
+	var options core.SWDOptions
+	if swd.options != nil {
+		options = *swd.options
+	}
+	return tenant.NewManager(swd.detector, options)
+}
+
+// Categories 返回当前实例使用的分类注册表
+func (swd *SWD) Categories() *category.Registry {
+	if loader, ok := swd.loader.(*dictionary.Loader); ok {
+		return loader.Registry()
+	}
+	return category.Default
+}
+
+// SetCategories 为当前实例设置独立的分类注册表，nil 表示使用 category.Default
+func (swd *SWD) SetCategories(reg *category.Registry) error {
+	loader, ok := swd.loader.(*dictionary.Loader)
+	if !ok {
+		return ErrRegistryUnsupported
+	}
+	loader.SetRegistry(reg)
+	return nil
+}
+
+// RegisterCategory 在当前实例的注册表中注册动态分类
+func (swd *SWD) RegisterCategory(name string) (category.Category, error) {
+	return swd.Categories().Register(name)
+}
+
+// RegisterSubCategory 在当前实例的注册表中注册父分类下的子分类
+func (swd *SWD) RegisterSubCategory(parent category.Category, name string) (category.Category, error) {
+	return swd.Categories().RegisterSub(parent, name)
+}
+
+// UnregisterCategory 从当前实例的注册表中注销动态分类
+func (swd *SWD) UnregisterCategory(name string) error {
+	return swd.Categories().Unregister(name)
+}
+
+// GetWordMeta 获取词条元数据
+func (swd *SWD) GetWordMeta(word string) (core.WordMeta, bool) {
+	return swd.loader.GetWordMeta(word)
+}
+
+// GetWordCategories 获取词条的全部分类
+func (swd *SWD) GetWordCategories(word string) []category.Category {
+	return swd.loader.GetWordCategories(word)
+}
+
+// Provenance 返回各来源为词条贡献的分类
+func (swd *SWD) Provenance(word string) (map[string][]category.Category, error) {
+	loader, ok := swd.loader.(*dictionary.Loader)
+	if !ok {
+		return nil, ErrProvenanceUnsupported
+	}
+	return loader.Provenance(word), nil
+}
+
 // AddWord 添加单个敏感词
 func (swd *SWD) AddWord(word string, category category.Category) error {
 	return swd.loader.AddWord(word, category)
@@ -98,6 +257,11 @@ func (swd *SWD) DetectIn(text string, categories ...category.Category) bool {
 	return swd.detector.DetectIn(text, categories...)
 }
 
+// DetectNotIn 检查文本是否包含指定分类以外的敏感词
+func (swd *SWD) DetectNotIn(text string, categories ...category.Category) bool {
+	return swd.detector.DetectNotIn(text, categories...)
+}
+
 // Match 返回文本中找到的第一个敏感词
 func (swd *SWD) Match(text string) *core.SensitiveWord {
 	if text == "" {
@@ -111,6 +275,11 @@ func (swd *SWD) MatchIn(text string, categories ...category.Category) *core.Sens
 	return swd.detector.MatchIn(text, categories...)
 }
 
+// MatchNotIn 返回文本中第一个指定分类以外的敏感词
+func (swd *SWD) MatchNotIn(text string, categories ...category.Category) *core.SensitiveWord {
+	return swd.detector.MatchNotIn(text, categories...)
+}
+
 // MatchAll 返回文本中所有敏感词
 func (swd *SWD) MatchAll(text string) []core.SensitiveWord {
 	return swd.detector.MatchAll(text)
@@ -121,6 +290,80 @@ func (swd *SWD) MatchAllIn(text string, categories ...category.Category) []core.
 	return swd.detector.MatchAllIn(text, categories...)
 }
 
+// MatchAllNotIn 返回文本中所有指定分类以外的敏感词
+func (swd *SWD) MatchAllNotIn(text string, categories ...category.Category) []core.SensitiveWord {
+	return swd.detector.MatchAllNotIn(text, categories...)
+}
+
+// EnableCategories 重新启用指定分类的检测
+func (swd *SWD) EnableCategories(categories ...category.Category) error {
+	toggler, ok := swd.detector.(core.CategoryToggler)
+	if !ok {
+		return ErrToggleUnsupported
+	}
+	toggler.EnableCategories(categories...)
+	return nil
+}
+
+// DisableCategories 停用指定分类的检测，词条仍保留在词库中
+func (swd *SWD) DisableCategories(categories ...category.Category) error {
+	toggler, ok := swd.detector.(core.CategoryToggler)
+	if !ok {
+		return ErrToggleUnsupported
+	}
+	toggler.DisableCategories(categories...)
+	return nil
+}
+
+// DisabledCategories 返回已停用的分类
+func (swd *SWD) DisabledCategories() *category.Set {
+	if toggler, ok := swd.detector.(core.CategoryToggler); ok {
+		return toggler.DisabledCategories()
+	}
+	return category.NewSet()
+}
+
+// Score 计算文本的风险评分
+func (swd *SWD) Score(text string) core.RiskScore {
+	return swd.detector.Score(text)
+}
+
+// Report 检测文本并生成结构化的检测报告
+func (swd *SWD) Report(text string) (core.DetectionReport, error) {
+	reporter, ok := swd.detector.(core.Reporter)
+	if !ok {
+		return core.DetectionReport{}, ErrReportUnsupported
+	}
+	return reporter.Report(text), nil
+}
+
+// SetInstrumentation 为支持指标采集的检测器、过滤器和加载器设置指标采集，inst 为 nil 时停止采集
+func (swd *SWD) SetInstrumentation(inst core.Instrumentation) error {
+	supported := false
+	for _, component := range []interface{}{swd.detector, swd.filter, swd.loader} {
+		if instrumented, ok := component.(core.Instrumented); ok {
+			instrumented.SetInstrumentation(inst)
+			supported = true
+		}
+	}
+	if !supported {
+		return ErrInstrumentationUnsupported
+	}
+	return nil
+}
+
+// SetLogger 为支持日志的组件设置日志记录器，为 nil 时使用 slog.Default()
+func (swd *SWD) SetLogger(logger *slog.Logger) {
+	if swd.options != nil {
+		swd.options.Logger = logger
+	}
+	for _, component := range []interface{}{swd.detector, swd.filter, swd.loader} {
+		if setter, ok := component.(core.LoggerSetter); ok {
+			setter.SetLogger(logger)
+		}
+	}
+}
+
 // Replace 使用指定的替换字符替换敏感词
 func (swd *SWD) Replace(text string, replacement rune) string {
 	return swd.filter.Replace(text, replacement)
@@ -131,6 +374,11 @@ func (swd *SWD) ReplaceIn(text string, replacement rune, categories ...category.
 	return swd.filter.ReplaceIn(text, replacement, categories...)
 }
 
+// ReplaceNotIn 使用指定的替换字符替换指定分类以外的敏感词
+func (swd *SWD) ReplaceNotIn(text string, replacement rune, categories ...category.Category) string {
+	return swd.filter.ReplaceNotIn(text, replacement, categories...)
+}
+
 // ReplaceWithAsterisk 使用星号替换敏感词
 func (swd *SWD) ReplaceWithAsterisk(text string) string {
 	return swd.filter.ReplaceWithAsterisk(text)
@@ -141,6 +389,11 @@ func (swd *SWD) ReplaceWithAsteriskIn(text string, categories ...category.Catego
 	return swd.filter.ReplaceWithAsteriskIn(text, categories...)
 }
 
+// ReplaceWithAsteriskNotIn 使用星号替换指定分类以外的敏感词
+func (swd *SWD) ReplaceWithAsteriskNotIn(text string, categories ...category.Category) string {
+	return swd.filter.ReplaceWithAsteriskNotIn(text, categories...)
+}
+
 // ReplaceWithStrategy 使用自定义策略替换敏感词
 func (swd *SWD) ReplaceWithStrategy(text string, strategy func(word core.SensitiveWord) string) string {
 	return swd.filter.ReplaceWithStrategy(text, strategy)
@@ -150,3 +403,27 @@ func (swd *SWD) ReplaceWithStrategy(text string, strategy func(word core.Sensiti
 func (swd *SWD) ReplaceWithStrategyIn(text string, strategy func(word core.SensitiveWord) string, categories ...category.Category) string {
 	return swd.filter.ReplaceWithStrategyIn(text, strategy, categories...)
 }
+
+// ReplaceWithStrategyNotIn 使用自定义策略替换指定分类以外的敏感词
+func (swd *SWD) ReplaceWithStrategyNotIn(text string, strategy func(word core.SensitiveWord) string, categories ...category.Category) string {
+	return swd.filter.ReplaceWithStrategyNotIn(text, strategy, categories...)
+}
+
+// MaskStrategy 返回替换策略：replacement 为单个字符时按敏感词在原文中所占的字符数重复，否则整词替换，为空时使用 *
+//
+// 预处理可能增删字符，原文区间的长度不一定等于词条长度，因此不能按 word.Word 计算长度。
+func MaskStrategy(replacement string) func(word core.SensitiveWord) string {
+	if replacement == "" {
+		replacement = "*"
+	}
+	if utf8.RuneCountInString(replacement) != 1 {
+		return func(core.SensitiveWord) string { return replacement }
+	}
+	return func(word core.SensitiveWord) string {
+		n := word.EndPos - word.StartPos
+		if n <= 0 {
+			n = utf8.RuneCountInString(word.Word)
+		}
+		return strings.Repeat(replacement, n)
+	}
+}
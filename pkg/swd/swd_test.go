@@ -1,8 +1,11 @@
 package swd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -1128,3 +1131,121 @@ func TestSWD_ReplaceWithStrategyIn(t *testing.T) {
 		})
 	}
 }
+
+// recorder 记录指标采集事件
+type recorder struct {
+	core.NopInstrumentation
+	mu       sync.Mutex
+	ops      []string
+	replaced []int
+	rebuilds int
+	words    int
+}
+
+func (r *recorder) ObserveScan(op string, _ []core.SensitiveWord, _ time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ops = append(r.ops, op)
+}
+
+func (r *recorder) ObserveReplace(replaced int, _ time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.replaced = append(r.replaced, replaced)
+}
+
+func (r *recorder) ObserveRebuild(int, time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rebuilds++
+}
+
+func (r *recorder) ObserveDictionary(total int, _ map[category.Category]int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.words = total
+}
+
+// TestSWD_SetInstrumentation 测试每次调用只报告一次事件
+func TestSWD_SetInstrumentation(t *testing.T) {
+	swd, err := New(NewDefaultFactory())
+	if err != nil {
+		t.Fatalf("Failed to create SWD instance: %v", err)
+	}
+	_ = swd.Clear()
+
+	r := &recorder{}
+	if err := swd.SetInstrumentation(r); err != nil {
+		t.Fatalf("SetInstrumentation() error = %v", err)
+	}
+	if err := swd.AddWords(map[string]category.Category{"赌场": category.Gambling, "傻瓜": category.Profanity}); err != nil {
+		t.Fatalf("AddWords() error = %v", err)
+	}
+
+	swd.Detect("去赌场")
+	swd.DetectIn("去赌场", category.Gambling)
+	swd.MatchNotIn("去赌场", category.Gambling)
+	swd.Score("去赌场")
+	swd.ReplaceWithAsterisk("傻瓜去赌场")
+	swd.ReplaceWithAsteriskIn("傻瓜去赌场", category.Gambling)
+
+	wantOps := []string{"Detect", "DetectIn", "MatchNotIn", "Score", "MatchAll", "MatchAllIn"}
+	if fmt.Sprint(r.ops) != fmt.Sprint(wantOps) {
+		t.Errorf("ObserveScan ops = %v, want %v", r.ops, wantOps)
+	}
+	if fmt.Sprint(r.replaced) != "[2 1]" {
+		t.Errorf("ObserveReplace = %v, want [2 1]", r.replaced)
+	}
+	if r.rebuilds == 0 || r.words != 2 {
+		t.Errorf("rebuilds = %d, words = %d, want rebuilds > 0 and 2 words", r.rebuilds, r.words)
+	}
+
+	if err := swd.SetInstrumentation(nil); err != nil {
+		t.Fatalf("SetInstrumentation(nil) error = %v", err)
+	}
+	swd.Detect("去赌场")
+	if len(r.ops) != len(wantOps) {
+		t.Errorf("ObserveScan called after instrumentation was removed: %v", r.ops)
+	}
+}
+
+// TestSWD_SetLogger 测试设置日志记录器后词库变更与重建写入该日志
+func TestSWD_SetLogger(t *testing.T) {
+	swd, err := New(NewDefaultFactory())
+	if err != nil {
+		t.Fatalf("Failed to create SWD instance: %v", err)
+	}
+
+	var buf bytes.Buffer
+	swd.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	if err := swd.AddWord("赌场", category.Gambling); err != nil {
+		t.Fatalf("AddWord() error = %v", err)
+	}
+
+	logs := buf.String()
+	for _, want := range []string{`msg="dictionary changed"`, `msg="rebuilt matcher"`} {
+		if !strings.Contains(logs, want) {
+			t.Errorf("logs = %q, want %s", logs, want)
+		}
+	}
+}
+
+// TestMaskStrategy 测试替换长度取敏感词在原文中的区间
+func TestMaskStrategy(t *testing.T) {
+	word := core.SensitiveWord{Word: "fuck", StartPos: 2, EndPos: 9}
+	tests := []struct {
+		replacement string
+		word        core.SensitiveWord
+		want        string
+	}{
+		{"", word, "*******"},
+		{"#", word, "#######"},
+		{"[已屏蔽]", word, "[已屏蔽]"},
+		{"*", core.SensitiveWord{Word: "赌场"}, "**"},
+	}
+	for _, tt := range tests {
+		if got := MaskStrategy(tt.replacement)(tt.word); got != tt.want {
+			t.Errorf("MaskStrategy(%q)(%+v) = %q, want %q", tt.replacement, tt.word, got, tt.want)
+		}
+	}
+}
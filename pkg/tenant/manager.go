@@ -0,0 +1,99 @@
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ttofTnT/go-swd/pkg/algorithm"
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/detector/preprocessor"
+)
+
+var (
+	// ErrNoBase 没有提供基础检测器
+	ErrNoBase = errors.New("no base detector provided")
+	// ErrEmptyTenantID 租户标识为空
+	ErrEmptyTenantID = errors.New("tenant id cannot be empty")
+)
+
+// Manager 管理共享同一基础检测器的多个租户
+type Manager struct {
+	base    core.Detector
+	options core.SWDOptions
+
+	mu      sync.RWMutex
+	tenants map[string]*Tenant
+}
+
+// NewManager 创建租户管理器，options 需与基础检测器的预处理、算法和替换配置一致
+//
+// 算法不支持或不支持替换检测时返回 algorithm.ErrUnsupported。
+func NewManager(base core.Detector, options core.SWDOptions) (*Manager, error) {
+	if base == nil {
+		return nil, ErrNoBase
+	}
+	algo, err := algorithm.New(options.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := algo.(core.CandidateMatcher); preprocessor.SubstitutionTable(options) != nil && !ok {
+		return nil, fmt.Errorf("%w: %s does not support substitutions", algorithm.ErrUnsupported, algo.Type())
+	}
+	return &Manager{
+		base:    base,
+		options: options,
+		tenants: make(map[string]*Tenant),
+	}, nil
+}
+
+// Tenant 获取租户，不存在时创建
+func (m *Manager) Tenant(id string) (*Tenant, error) {
+	if id == "" {
+		return nil, ErrEmptyTenantID
+	}
+
+	m.mu.RLock()
+	t, ok := m.tenants[id]
+	m.mu.RUnlock()
+	if ok {
+		return t, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if t, ok := m.tenants[id]; ok {
+		return t, nil
+	}
+	t = newTenant(id, m.base, m.options)
+	m.tenants[id] = t
+	return t, nil
+}
+
+// Lookup 获取已存在的租户
+func (m *Manager) Lookup(id string) (*Tenant, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	t, ok := m.tenants[id]
+	return t, ok
+}
+
+// Remove 移除租户
+func (m *Manager) Remove(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tenants, id)
+}
+
+// IDs 返回排序后的所有租户标识
+func (m *Manager) IDs() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := make([]string, 0, len(m.tenants))
+	for id := range m.tenants {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
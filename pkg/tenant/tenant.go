@@ -0,0 +1,410 @@
+package tenant
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/ttofTnT/go-swd/pkg/algorithm"
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/detector"
+	"github.com/ttofTnT/go-swd/pkg/detector/preprocessor"
+	"github.com/ttofTnT/go-swd/pkg/filter"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// ErrEmptyWord 词条为空
+var ErrEmptyWord = errors.New("word cannot be empty")
+
+// Tenant 租户作用域的检测器与过滤器
+//
+// 检测结果由共享的基础检测器与租户自有的覆盖词库合并而成：同一位置的同一词条以覆盖词库为准，
+// 落在白名单词条出现范围内的匹配会被忽略。覆盖词库通常很小，每次变更都会整体重建。
+type Tenant struct {
+	id         string
+	base       core.Detector
+	preprocess *preprocessor.Preprocessor
+	subs       core.Substitutions // 替换字符表，与基础检测器一致，未启用时为 nil
+	options    core.SWDOptions
+	filter     core.Filter
+
+	mu      sync.RWMutex
+	words   map[string]category.Category
+	meta    map[string]core.WordMeta
+	allowed map[string]struct{}
+	overlay core.Algorithm // 覆盖词库，只读，变更时整体替换
+	allow   core.Algorithm // 白名单，只读，变更时整体替换
+}
+
+// newTenant 创建租户
+func newTenant(id string, base core.Detector, options core.SWDOptions) *Tenant {
+	t := &Tenant{
+		id:         id,
+		base:       base,
+		preprocess: preprocessor.NewPreprocessor(options),
+		subs:       preprocessor.SubstitutionTable(options),
+		options:    options,
+		words:      make(map[string]category.Category),
+		meta:       make(map[string]core.WordMeta),
+		allowed:    make(map[string]struct{}),
+	}
+	t.overlay = t.buildOverlay(t.words)
+	t.allow = build(nil)
+	t.filter = filter.NewFilter(t)
+	return t
+}
+
+// build 构建只读自动机
+func build(words map[string]category.Category) core.Algorithm {
+	ac := algorithm.NewAhoCorasick()
+	_ = ac.Build(words)
+	return ac
+}
+
+// buildOverlay 以基础检测器的算法构建只读的覆盖词库，算法已由 NewManager 校验
+func (t *Tenant) buildOverlay(words map[string]category.Category) core.Algorithm {
+	algo, err := algorithm.New(t.options.Algorithm)
+	if err != nil {
+		return build(words)
+	}
+	_ = algo.Build(words)
+	return algo
+}
+
+// ID 返回租户标识
+func (t *Tenant) ID() string {
+	return t.id
+}
+
+// AddWord 向覆盖词库添加单个敏感词
+func (t *Tenant) AddWord(word string, cat category.Category) error {
+	return t.AddWords(map[string]category.Category{word: cat})
+}
+
+// AddWordWithMeta 向覆盖词库添加带元数据的敏感词
+func (t *Tenant) AddWordWithMeta(word string, cat category.Category, meta core.WordMeta) error {
+	if word == "" {
+		return ErrEmptyWord
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.words[word] = cat
+	if meta.IsZero() {
+		delete(t.meta, word)
+	} else {
+		t.meta[word] = meta
+	}
+	t.overlay = t.buildOverlay(t.words)
+	return nil
+}
+
+// AddWords 向覆盖词库批量添加敏感词
+func (t *Tenant) AddWords(words map[string]category.Category) error {
+	for word := range words {
+		if word == "" {
+			return ErrEmptyWord
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for word, cat := range words {
+		t.words[word] = cat
+	}
+	t.overlay = t.buildOverlay(t.words)
+	return nil
+}
+
+// RemoveWord 从覆盖词库移除敏感词，基础词库不受影响
+func (t *Tenant) RemoveWord(word string) error {
+	return t.RemoveWords([]string{word})
+}
+
+// RemoveWords 从覆盖词库批量移除敏感词
+func (t *Tenant) RemoveWords(words []string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, word := range words {
+		delete(t.words, word)
+		delete(t.meta, word)
+	}
+	t.overlay = t.buildOverlay(t.words)
+	return nil
+}
+
+// Clear 清空覆盖词库
+func (t *Tenant) Clear() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.words = make(map[string]category.Category)
+	t.meta = make(map[string]core.WordMeta)
+	t.overlay = t.buildOverlay(t.words)
+	return nil
+}
+
+// GetWords 返回覆盖词库
+func (t *Tenant) GetWords() map[string]category.Category {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	words := make(map[string]category.Category, len(t.words))
+	for word, cat := range t.words {
+		words[word] = cat
+	}
+	return words
+}
+
+// Allow 将词条加入白名单，文本中该词条出现范围内的所有匹配都会被忽略
+func (t *Tenant) Allow(words ...string) error {
+	for _, word := range words {
+		if word == "" {
+			return ErrEmptyWord
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, word := range words {
+		t.allowed[word] = struct{}{}
+	}
+	t.rebuildAllow()
+	return nil
+}
+
+// Disallow 将词条移出白名单
+func (t *Tenant) Disallow(words ...string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, word := range words {
+		delete(t.allowed, word)
+	}
+	t.rebuildAllow()
+}
+
+// Allowlist 返回排序后的白名单
+func (t *Tenant) Allowlist() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	words := make([]string, 0, len(t.allowed))
+	for word := range t.allowed {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+// rebuildAllow 重建白名单自动机，调用方需持有写锁
+func (t *Tenant) rebuildAllow() {
+	words := make(map[string]category.Category, len(t.allowed))
+	for word := range t.allowed {
+		words[word] = category.None
+	}
+	t.allow = build(words)
+}
+
+// matchAll 合并基础词库与覆盖词库的匹配结果，并剔除白名单范围内的匹配
+func (t *Tenant) matchAll(text string) []core.SensitiveWord {
+	if text == "" {
+		return nil
+	}
+	baseMatches := t.base.MatchAll(text)
+
+	// 基础检测器返回原文位置，覆盖词库与白名单的匹配同样映射回原文
+	processedText, offsets := t.preprocess.ProcessWithOffsets(text)
+	t.mu.RLock()
+	overlay, allow, meta := t.overlay, t.allow, t.meta
+	var overlayMatches []core.SensitiveWord
+	if t.subs != nil {
+		// 与基础检测器相同，按替换字符表匹配候选字符
+		overlayMatches = overlay.(core.CandidateMatcher).MatchAllCandidates(processedText, t.subs)
+	} else {
+		overlayMatches = overlay.MatchAll(processedText)
+	}
+	for i := range overlayMatches {
+		if m, ok := meta[overlayMatches[i].Word]; ok {
+			overlayMatches[i].Meta = &m
+		}
+	}
+	t.mu.RUnlock()
+	allowed := allow.MatchAll(processedText)
+	preprocessor.ToOriginal(overlayMatches, offsets)
+	preprocessor.ToOriginal(allowed, offsets)
+
+	// 基础检测器停用的分类对覆盖词库同样生效
+	if toggler, ok := t.base.(core.CategoryToggler); ok {
+		if disabled := toggler.DisabledCategories(); !disabled.IsEmpty() {
+			enabled := overlayMatches[:0]
+			for _, m := range overlayMatches {
+				if !disabled.Covers(m.Category) {
+					enabled = append(enabled, m)
+				}
+			}
+			overlayMatches = enabled
+		}
+	}
+
+	// 同一位置的同一词条以覆盖词库为准
+	type span struct{ start, end int }
+	seen := make(map[span]struct{}, len(overlayMatches))
+	for _, m := range overlayMatches {
+		seen[span{m.StartPos, m.EndPos}] = struct{}{}
+	}
+
+	matches := make([]core.SensitiveWord, 0, len(baseMatches)+len(overlayMatches))
+	for _, m := range baseMatches {
+		if _, ok := seen[span{m.StartPos, m.EndPos}]; !ok {
+			matches = append(matches, m)
+		}
+	}
+	matches = append(matches, overlayMatches...)
+
+	result := matches[:0]
+	for _, m := range matches {
+		if !covered(m, allowed) {
+			result = append(result, m)
+		}
+	}
+
+	// 与基础检测器保持一致：按结束位置排序，结束位置相同时较长的在前
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].EndPos != result[j].EndPos {
+			return result[i].EndPos < result[j].EndPos
+		}
+		return result[i].StartPos < result[j].StartPos
+	})
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// covered 判断匹配是否落在某个白名单词条的出现范围内
+func covered(match core.SensitiveWord, allowed []core.SensitiveWord) bool {
+	for _, a := range allowed {
+		if a.StartPos <= match.StartPos && match.EndPos <= a.EndPos {
+			return true
+		}
+	}
+	return false
+}
+
+// selectMatches 筛选属于（include 为 true）或不属于指定分类的匹配结果
+func selectMatches(matches []core.SensitiveWord, categories []category.Category, include bool) []core.SensitiveWord {
+	var result []core.SensitiveWord
+	for _, match := range matches {
+		if match.In(categories...) == include {
+			result = append(result, match)
+		}
+	}
+	return result
+}
+
+// Detect 检查文本是否包含敏感词
+func (t *Tenant) Detect(text string) bool {
+	return len(t.matchAll(text)) > 0
+}
+
+// DetectIn 检查文本是否包含指定分类的敏感词
+func (t *Tenant) DetectIn(text string, categories ...category.Category) bool {
+	return len(t.MatchAllIn(text, categories...)) > 0
+}
+
+// DetectNotIn 检查文本是否包含指定分类以外的敏感词
+func (t *Tenant) DetectNotIn(text string, categories ...category.Category) bool {
+	return len(t.MatchAllNotIn(text, categories...)) > 0
+}
+
+// Match 返回文本中第一个敏感词
+func (t *Tenant) Match(text string) *core.SensitiveWord {
+	matches := t.matchAll(text)
+	if len(matches) == 0 {
+		return nil
+	}
+	return &matches[0]
+}
+
+// MatchIn 返回文本中第一个指定分类的敏感词
+func (t *Tenant) MatchIn(text string, categories ...category.Category) *core.SensitiveWord {
+	matches := t.MatchAllIn(text, categories...)
+	if len(matches) == 0 {
+		return nil
+	}
+	return &matches[0]
+}
+
+// MatchNotIn 返回文本中第一个指定分类以外的敏感词
+func (t *Tenant) MatchNotIn(text string, categories ...category.Category) *core.SensitiveWord {
+	matches := t.MatchAllNotIn(text, categories...)
+	if len(matches) == 0 {
+		return nil
+	}
+	return &matches[0]
+}
+
+// MatchAll 返回文本中所有敏感词
+func (t *Tenant) MatchAll(text string) []core.SensitiveWord {
+	return t.matchAll(text)
+}
+
+// MatchAllIn 返回文本中所有指定分类的敏感词
+func (t *Tenant) MatchAllIn(text string, categories ...category.Category) []core.SensitiveWord {
+	if len(categories) == 0 {
+		return nil
+	}
+	return selectMatches(t.matchAll(text), categories, true)
+}
+
+// MatchAllNotIn 返回文本中所有指定分类以外的敏感词
+func (t *Tenant) MatchAllNotIn(text string, categories ...category.Category) []core.SensitiveWord {
+	return selectMatches(t.matchAll(text), categories, false)
+}
+
+// Score 计算文本的风险评分
+func (t *Tenant) Score(text string) core.RiskScore {
+	return detector.ScoreMatches(t.matchAll(text), t.options)
+}
+
+// Replace 使用指定的替换字符替换敏感词
+func (t *Tenant) Replace(text string, replacement rune) string {
+	return t.filter.Replace(text, replacement)
+}
+
+// ReplaceIn 使用指定的替换字符替换指定分类的敏感词
+func (t *Tenant) ReplaceIn(text string, replacement rune, categories ...category.Category) string {
+	return t.filter.ReplaceIn(text, replacement, categories...)
+}
+
+// ReplaceNotIn 使用指定的替换字符替换指定分类以外的敏感词
+func (t *Tenant) ReplaceNotIn(text string, replacement rune, categories ...category.Category) string {
+	return t.filter.ReplaceNotIn(text, replacement, categories...)
+}
+
+// ReplaceWithAsterisk 使用 * 号替换敏感词
+func (t *Tenant) ReplaceWithAsterisk(text string) string {
+	return t.filter.ReplaceWithAsterisk(text)
+}
+
+// ReplaceWithAsteriskIn 使用 * 号替换指定分类的敏感词
+func (t *Tenant) ReplaceWithAsteriskIn(text string, categories ...category.Category) string {
+	return t.filter.ReplaceWithAsteriskIn(text, categories...)
+}
+
+// ReplaceWithAsteriskNotIn 使用 * 号替换指定分类以外的敏感词
+func (t *Tenant) ReplaceWithAsteriskNotIn(text string, categories ...category.Category) string {
+	return t.filter.ReplaceWithAsteriskNotIn(text, categories...)
+}
+
+// ReplaceWithStrategy 使用自定义替换策略替换敏感词
+func (t *Tenant) ReplaceWithStrategy(text string, strategy func(word core.SensitiveWord) string) string {
+	return t.filter.ReplaceWithStrategy(text, strategy)
+}
+
+// ReplaceWithStrategyIn 使用自定义替换策略替换指定分类的敏感词
+func (t *Tenant) ReplaceWithStrategyIn(text string, strategy func(word core.SensitiveWord) string, categories ...category.Category) string {
+	return t.filter.ReplaceWithStrategyIn(text, strategy, categories...)
+}
+
+// ReplaceWithStrategyNotIn 使用自定义替换策略替换指定分类以外的敏感词
+func (t *Tenant) ReplaceWithStrategyNotIn(text string, strategy func(word core.SensitiveWord) string, categories ...category.Category) string {
+	return t.filter.ReplaceWithStrategyNotIn(text, strategy, categories...)
+}
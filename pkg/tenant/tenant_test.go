@@ -0,0 +1,204 @@
+package tenant
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/detector"
+	"github.com/ttofTnT/go-swd/pkg/types/category"
+)
+
+// newBase 创建使用固定词库的基础检测器
+func newBase(t *testing.T) core.Detector {
+	t.Helper()
+	base, err := detector.NewDetector(core.SWDOptions{})
+	if err != nil {
+		t.Fatalf("创建检测器失败: %v", err)
+	}
+	base.(core.Observer).OnWordsChanged(map[string]category.Category{
+		"赌场": category.Gambling,
+		"骗子": category.Scam,
+	})
+	return base
+}
+
+func TestManager(t *testing.T) {
+	if _, err := NewManager(nil, core.SWDOptions{}); err != ErrNoBase {
+		t.Errorf("NewManager(nil) 错误 = %v, 期望 %v", err, ErrNoBase)
+	}
+
+	m, err := NewManager(newBase(t), core.SWDOptions{})
+	if err != nil {
+		t.Fatalf("创建租户管理器失败: %v", err)
+	}
+	if _, err := m.Tenant(""); err != ErrEmptyTenantID {
+		t.Errorf("Tenant(\"\") 错误 = %v, 期望 %v", err, ErrEmptyTenantID)
+	}
+
+	a, _ := m.Tenant("a")
+	again, _ := m.Tenant("a")
+	if a != again {
+		t.Error("同一标识应返回同一租户")
+	}
+	_, _ = m.Tenant("b")
+	if got := m.IDs(); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("IDs() = %v, 期望 [a b]", got)
+	}
+
+	m.Remove("a")
+	if _, ok := m.Lookup("a"); ok {
+		t.Error("Remove() 后仍能找到租户")
+	}
+}
+
+func TestTenant_Overlay(t *testing.T) {
+	m, _ := NewManager(newBase(t), core.SWDOptions{})
+	a, _ := m.Tenant("a")
+	b, _ := m.Tenant("b")
+
+	if err := a.AddWord("竞品", category.Custom); err != nil {
+		t.Fatalf("AddWord() 失败: %v", err)
+	}
+	if err := a.AddWordWithMeta("骗子", category.Custom, core.WordMeta{Severity: 5}); err != nil {
+		t.Fatalf("AddWordWithMeta() 失败: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		tenant *Tenant
+		text   string
+		want   []string
+	}{
+		{"基础词库", b, "这里有赌场", []string{"赌场"}},
+		{"覆盖词库", a, "竞品和赌场", []string{"竞品", "赌场"}},
+		{"其他租户不受影响", b, "竞品和赌场", []string{"赌场"}},
+		{"无匹配", a, "正常文本", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, match := range tt.tenant.MatchAll(tt.text) {
+				got = append(got, match.Word)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchAll(%q) = %v, 期望 %v", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	// 同一词条以覆盖词库为准
+	matches := a.MatchAll("骗子")
+	if len(matches) != 1 || matches[0].Category != category.Custom || matches[0].Meta == nil || matches[0].Meta.Severity != 5 {
+		t.Errorf("MatchAll(骗子) = %+v, 期望覆盖词库的分类与元数据", matches)
+	}
+	if match := b.Match("骗子"); match == nil || match.Category != category.Scam {
+		t.Errorf("Match(骗子) = %+v, 期望基础词库的分类", match)
+	}
+
+	if !a.DetectIn("竞品", category.Custom) || a.DetectIn("竞品", category.Gambling) {
+		t.Error("DetectIn() 分类过滤结果错误")
+	}
+	if got := a.ReplaceWithAsterisk("竞品和赌场"); got != "**和**" {
+		t.Errorf("ReplaceWithAsterisk() = %q, 期望 %q", got, "**和**")
+	}
+
+	_ = a.RemoveWord("竞品")
+	if a.Detect("竞品") {
+		t.Error("RemoveWord() 后仍能检测到")
+	}
+}
+
+func TestTenant_Leetspeak(t *testing.T) {
+	for _, algo := range []core.AlgorithmType{core.AlgorithmAhoCorasick, core.AlgorithmTrie} {
+		t.Run(string(algo), func(t *testing.T) {
+			options := core.SWDOptions{Algorithm: algo, IgnoreCase: true, EnableLeetspeak: true}
+			base, err := detector.NewDetector(options)
+			if err != nil {
+				t.Fatalf("创建检测器失败: %v", err)
+			}
+			base.(core.Observer).OnWordsChanged(map[string]category.Category{"scam": category.Scam})
+
+			m, err := NewManager(base, options)
+			if err != nil {
+				t.Fatalf("创建租户管理器失败: %v", err)
+			}
+			a, _ := m.Tenant("a")
+			_ = a.AddWord("rival", category.Custom)
+
+			// 覆盖词库与基础词库同样识别 leetspeak 变体
+			var got []string
+			for _, match := range a.MatchAll("R1V4L and $c4m") {
+				got = append(got, match.Word)
+			}
+			if want := []string{"rival", "scam"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("MatchAll() = %v, 期望 %v", got, want)
+			}
+			if got := a.ReplaceWithAsterisk("R1V4L!"); got != "*****!" {
+				t.Errorf("ReplaceWithAsterisk() = %q, 期望 %q", got, "*****!")
+			}
+		})
+	}
+
+	if _, err := NewManager(newBase(t), core.SWDOptions{Algorithm: "dfa"}); err == nil {
+		t.Error("NewManager() 未知算法应返回错误")
+	}
+}
+
+func TestTenant_Allowlist(t *testing.T) {
+	m, _ := NewManager(newBase(t), core.SWDOptions{})
+	a, _ := m.Tenant("a")
+
+	if err := a.Allow("赌场风云", ""); err != ErrEmptyWord {
+		t.Errorf("Allow(\"\") 错误 = %v, 期望 %v", err, ErrEmptyWord)
+	}
+	_ = a.Allow("赌场风云")
+
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"电影赌场风云", "电影赌场风云"},
+		{"赌场风云里的赌场", "赌场风云里的**"},
+	}
+	for _, tt := range tests {
+		if got := a.ReplaceWithAsterisk(tt.text); got != tt.want {
+			t.Errorf("ReplaceWithAsterisk(%q) = %q, 期望 %q", tt.text, got, tt.want)
+		}
+	}
+
+	a.Disallow("赌场风云")
+	if len(a.Allowlist()) != 0 || !a.Detect("赌场风云") {
+		t.Error("Disallow() 后白名单仍然生效")
+	}
+}
+
+func TestTenant_OverlappingReplace(t *testing.T) {
+	m, _ := NewManager(newBase(t), core.SWDOptions{})
+	a, _ := m.Tenant("a")
+	_ = a.AddWord("大赌场", category.Gambling)
+
+	if got := a.ReplaceWithAsterisk("去大赌场玩"); got != "去***玩" {
+		t.Errorf("ReplaceWithAsterisk() = %q, 期望 %q", got, "去***玩")
+	}
+}
+
+func TestTenant_Concurrent(t *testing.T) {
+	m, _ := NewManager(newBase(t), core.SWDOptions{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tenant, _ := m.Tenant(string(rune('a' + i%2)))
+			for j := 0; j < 100; j++ {
+				_ = tenant.AddWord(string(rune('甲'+j)), category.Custom)
+				tenant.Detect("赌场")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
@@ -2,20 +2,15 @@ package category
 
 import (
 	"fmt"
-	"sync"
+	"math/bits"
 )
 
 // Category 敏感词分类
+//
+// 静态分类为互不重叠的正数位，可用 | 组合；动态分类为负数序号，
+// 数量不受 64 位限制，但不能用 | 组合，多个分类请使用 Set。
 type Category int
 
-var (
-	mu              sync.RWMutex
-	categoryMap     = make(map[string]Category) // name => Category
-	categoryString  = make(map[Category]string) // Category => name
-	nextDynamicVal  Category                    // 动态分类起始值
-	predefinedFlags Category                    // 所有静态分类合集
-)
-
 // 静态分类常量
 const (
 	None           Category = 0
@@ -30,93 +25,146 @@ const (
 	Custom                              // 自定义
 )
 
-func init() {
-	// 注册静态分类
-	registerStatic("未分类", None)
-	registerStatic("涉黄", Pornography)
-	registerStatic("涉政", Political)
-	registerStatic("暴力", Violence)
-	registerStatic("赌博", Gambling)
-	registerStatic("毒品", Drugs)
-	registerStatic("脏话", Profanity)
-	registerStatic("歧视", Discrimination)
-	registerStatic("诈骗", Scam)
-	registerStatic("自定义", Custom)
+// predefinedFlags 所有静态分类合集
+const predefinedFlags = Pornography | Political | Violence | Gambling | Drugs | Profanity | Discrimination | Scam | Custom
 
-	// 动态分类起始值 = 静态最大值 << 1
-	nextDynamicVal = Custom << 1
-}
+// firstDynamicIndex 动态分类的起始序号，紧接最后一个静态分类的位序号
+var firstDynamicIndex = bits.TrailingZeros64(uint64(Custom)) + 1
 
-// 注册静态分类
-func registerStatic(name string, val Category) {
-	categoryMap[name] = val
-	categoryString[val] = name
-	predefinedFlags |= val
+// IsDynamic 判断是否为动态分类
+func (c Category) IsDynamic() bool {
+	return c < 0
 }
 
-// RegisterCategory 注册一个新分类（如果已存在则返回旧值）
-func RegisterCategory(name string) Category {
-	mu.Lock()
-	defer mu.Unlock()
-
-	if val, ok := categoryMap[name]; ok {
-		return val
+// index 返回单个分类在 Set 中的序号
+func (c Category) index() int {
+	if c.IsDynamic() {
+		return int(-c)
 	}
+	return bits.TrailingZeros64(uint64(c))
+}
 
-	val := nextDynamicVal
-	nextDynamicVal <<= 1
-	categoryMap[name] = val
-	categoryString[val] = name
-	return val
+// fromIndex 根据序号还原单个分类
+func fromIndex(i int) Category {
+	if i < firstDynamicIndex {
+		return Category(1) << i
+	}
+	return -Category(i)
 }
 
-// ParseCategory 获取分类值（从名称）
-func ParseCategory(name string) (Category, bool) {
-	mu.RLock()
-	defer mu.RUnlock()
-	val, ok := categoryMap[name]
-	return val, ok
+// split 将分类拆分为单个分类
+func (c Category) split() []Category {
+	if c.IsDynamic() {
+		return []Category{c}
+	}
+	var cats []Category
+	for rest := uint64(c); rest != 0; rest &= rest - 1 {
+		cats = append(cats, Category(1)<<bits.TrailingZeros64(rest))
+	}
+	return cats
 }
 
-// String 返回分类名称
+// String 返回分类名称，子分类返回完整路径
+//
+// 静态分类使用默认注册表中的名称，动态分类返回注册它的注册表中的名称。
 func (c Category) String() string {
-	mu.RLock()
-	defer mu.RUnlock()
-	if name, ok := categoryString[c]; ok {
+	if c.IsDynamic() {
+		if name, ok := dynamicNames.Load(c); ok {
+			return name.(string)
+		}
+	} else if name, ok := Default.Name(c); ok {
 		return name
 	}
 	return fmt.Sprintf("未知分类(%d)", c)
 }
 
-// Contains 判断是否包含某分类（支持组合）
+// Contains 判断是否包含另一分类（组合分类需包含其全部分类，父分类包含其所有子分类）
 func (c Category) Contains(other Category) bool {
 	if other == None {
 		return c == None
 	}
-	if !other.IsValid() {
-		return false
+	for {
+		if c.containsFlat(other) {
+			return true
+		}
+		parent, ok := other.Parent()
+		if !ok {
+			return false
+		}
+		other = parent
+	}
+}
+
+// containsFlat 不考虑层级时判断是否包含另一分类
+func (c Category) containsFlat(other Category) bool {
+	if other.IsDynamic() || c.IsDynamic() {
+		return c == other
+	}
+	return c&other == other
+}
+
+// Parent 返回子分类的父分类
+func (c Category) Parent() (Category, bool) {
+	if !c.IsDynamic() {
+		return None, false
+	}
+	parent, ok := parents.Load(c)
+	if !ok {
+		return None, false
 	}
-	return c&other != 0
+	return parent.(Category), true
 }
 
-// IsValid 判断是否合法（静态或动态）
+// Ancestors 返回分类自身及其所有上级分类，由近及远
+func (c Category) Ancestors() []Category {
+	cats := []Category{c}
+	for parent, ok := c.Parent(); ok; parent, ok = parent.Parent() {
+		cats = append(cats, parent)
+	}
+	return cats
+}
+
+// IsValid 判断是否为默认注册表中的单个分类
 func (c Category) IsValid() bool {
-	mu.RLock()
-	defer mu.RUnlock()
-	_, ok := categoryString[c]
-	return ok
+	return Default.IsValid(c)
 }
 
-// All 返回当前所有已注册的分类（含静态和动态）
+// All 返回默认注册表中可用 | 组合的所有分类，即所有静态分类
+//
+// 动态分类无法用 | 组合，不包含在返回值中；需要包含动态分类时使用 List 或 AllSet。
 func All() Category {
-	mu.RLock()
-	defer mu.RUnlock()
+	return predefinedFlags
+}
 
-	var all Category
-	for cat := range categoryString {
-		if cat != None {
-			all |= cat
-		}
-	}
-	return all
+// List 返回默认注册表中的所有分类（含动态分类，不含 None），按序号排序，
+// 可展开传给 DetectIn 等变参方法：DetectIn(text, category.List()...)
+func List() []Category {
+	return Default.All().Categories()
+}
+
+// AllSet 返回默认注册表中所有分类的集合
+func AllSet() *Set {
+	return Default.All()
+}
+
+// RegisterCategory 在默认注册表中注册一个新分类（如果已存在则返回旧值），名称为空时返回 None
+func RegisterCategory(name string) Category {
+	cat, _ := Default.Register(name)
+	return cat
+}
+
+// RegisterSubCategory 在默认注册表中注册父分类下的子分类（如果已存在则返回旧值），失败时返回 None
+func RegisterSubCategory(parent Category, name string) Category {
+	cat, _ := Default.RegisterSub(parent, name)
+	return cat
+}
+
+// UnregisterCategory 从默认注册表中注销动态分类
+func UnregisterCategory(name string) error {
+	return Default.Unregister(name)
+}
+
+// ParseCategory 获取分类值（从名称或 ASCII 标识，标识不区分大小写）
+func ParseCategory(name string) (Category, bool) {
+	return Default.Parse(name)
 }
@@ -0,0 +1,142 @@
+package category
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// combineSeparator 文本形式中组合分类的分隔符
+const combineSeparator = "|"
+
+// ID 返回分类在默认注册表中的 ASCII 标识，组合分类以 | 连接，没有标识的动态分类返回注册名称
+func (c Category) ID() string {
+	return Default.Format(c)
+}
+
+// DisplayName 返回分类在默认注册表中指定语言的显示名称
+func (c Category) DisplayName(lang string) string {
+	return Default.DisplayName(c, lang)
+}
+
+// SetDisplayName 设置分类在默认注册表中指定语言的显示名称
+func SetDisplayName(c Category, lang, name string) error {
+	return Default.SetDisplayName(c, lang, name)
+}
+
+// Format 返回分类的稳定文本形式，可由 ParseText 还原
+//
+// 优先使用 ASCII 标识，组合分类以 | 连接；没有标识时使用注册名称，未注册的分类使用数值。
+func (r *Registry) Format(c Category) string {
+	if id, ok := r.ID(c); ok {
+		return id
+	}
+	if !c.IsDynamic() && c != None {
+		singles := c.split()
+		if len(singles) > 1 {
+			ids := make([]string, len(singles))
+			for i, single := range singles {
+				ids[i] = r.Format(single)
+			}
+			return strings.Join(ids, combineSeparator)
+		}
+	}
+	if name, ok := r.Name(c); ok {
+		return name
+	}
+	return strconv.Itoa(int(c))
+}
+
+// ParseText 解析 Format 返回的文本形式，也接受名称和数值
+func (r *Registry) ParseText(text string) (Category, error) {
+	if c, ok := r.Parse(text); ok {
+		return c, nil
+	}
+	if parts := strings.Split(text, combineSeparator); len(parts) > 1 {
+		var combined Category
+		for _, part := range parts {
+			c, ok := r.Parse(strings.TrimSpace(part))
+			if !ok || c.IsDynamic() {
+				return None, fmt.Errorf("%w: %q", ErrNotRegistered, text)
+			}
+			combined |= c
+		}
+		return combined, nil
+	}
+	if n, err := strconv.Atoi(text); err == nil {
+		return Category(n), nil
+	}
+	return None, fmt.Errorf("%w: %q", ErrNotRegistered, text)
+}
+
+// ownerOf 返回注册了分类的注册表，静态分类和未注册的分类返回 Default
+func ownerOf(c Category) *Registry {
+	if c.IsDynamic() {
+		if r, ok := owners.Load(c); ok {
+			return r.(*Registry)
+		}
+	}
+	return Default
+}
+
+// parseDynamic 在所有注册表的动态分类中解析文本，恰好匹配一个分类时返回该分类
+func parseDynamic(text string) (Category, bool) {
+	found := None
+	registries := make(map[*Registry]struct{})
+	owners.Range(func(_, value interface{}) bool {
+		registries[value.(*Registry)] = struct{}{}
+		return true
+	})
+	for r := range registries {
+		c, ok := r.Parse(text)
+		if !ok || !c.IsDynamic() {
+			continue
+		}
+		if found != None && found != c {
+			return None, false // 多个注册表中的同名分类无法区分
+		}
+		found = c
+	}
+	return found, found != None
+}
+
+// MarshalText 实现 encoding.TextMarshaler，输出分类在所属注册表中的 ASCII 标识
+func (c Category) MarshalText() ([]byte, error) {
+	return []byte(ownerOf(c).Format(c)), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，接受标识、名称或数值
+//
+// 先在 Default 中解析，再查找其他注册表中的动态分类；多个注册表存在同名分类时返回错误。
+func (c *Category) UnmarshalText(text []byte) error {
+	parsed, err := Default.ParseText(string(text))
+	if err != nil {
+		dynamic, ok := parseDynamic(string(text))
+		if !ok {
+			return err
+		}
+		parsed = dynamic
+	}
+	*c = parsed
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler，输出分类在所属注册表中的 ASCII 标识字符串
+func (c Category) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ownerOf(c).Format(c))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，同时兼容旧的数值形式
+func (c *Category) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var n int
+		if json.Unmarshal(data, &n) != nil {
+			return fmt.Errorf("invalid category: %s", data)
+		}
+		*c = Category(n)
+		return nil
+	}
+	return c.UnmarshalText([]byte(text))
+}
@@ -0,0 +1,169 @@
+package category
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestCategory_ID(t *testing.T) {
+	tests := []struct {
+		category Category
+		want     string
+	}{
+		{None, "none"},
+		{Pornography, "pornography"},
+		{Political | Violence, "political|violence"},
+	}
+	for _, tt := range tests {
+		if got := tt.category.ID(); got != tt.want {
+			t.Errorf("%v.ID() = %q, 期望 %q", tt.category, got, tt.want)
+		}
+		if got, err := Default.ParseText(tt.want); err != nil || got != tt.category {
+			t.Errorf("ParseText(%q) = %v, %v, 期望 %v", tt.want, got, err, tt.category)
+		}
+	}
+
+	if got, ok := ParseCategory("Pornography"); !ok || got != Pornography {
+		t.Errorf("ParseCategory(Pornography) = %v, %v, 期望 %v, true", got, ok, Pornography)
+	}
+	if _, err := Default.ParseText("不存在"); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("ParseText(不存在) 错误 = %v, 期望 %v", err, ErrNotRegistered)
+	}
+}
+
+func TestRegistry_DynamicID(t *testing.T) {
+	r := NewRegistry()
+	ai, _ := r.Register("AI")
+	if id, ok := r.ID(ai); !ok || id != "ai" {
+		t.Errorf("ID() = %q, %v, 期望 ai, true", id, ok)
+	}
+
+	leaders, _ := r.RegisterSub(Political, "领导人")
+	if _, ok := r.ID(leaders); ok {
+		t.Error("非 ASCII 名称不应自动生成标识")
+	}
+	if got := r.Format(leaders); got != "涉政/领导人" {
+		t.Errorf("Format() = %q, 期望使用名称", got)
+	}
+	if err := r.SetID(leaders, "Political/Leaders"); err != nil {
+		t.Fatalf("SetID() 错误 = %v", err)
+	}
+	if got, ok := r.Parse("political/leaders"); !ok || got != leaders {
+		t.Errorf("Parse(political/leaders) = %v, %v, 期望 %v, true", got, ok, leaders)
+	}
+	speech, _ := r.RegisterSub(leaders, "speech")
+	if id, _ := r.ID(speech); id != "political/leaders/speech" {
+		t.Errorf("子分类标识 = %q, 期望 political/leaders/speech", id)
+	}
+
+	if err := r.SetID(leaders, "无效"); !errors.Is(err, ErrInvalidID) {
+		t.Errorf("SetID(无效) 错误 = %v, 期望 %v", err, ErrInvalidID)
+	}
+	if err := r.SetID(ai, "political/leaders"); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("SetID(重复) 错误 = %v, 期望 %v", err, ErrDuplicateID)
+	}
+	if err := r.SetID(Political, "politics"); !errors.Is(err, ErrStaticCategory) {
+		t.Errorf("SetID(静态分类) 错误 = %v, 期望 %v", err, ErrStaticCategory)
+	}
+}
+
+func TestRegistry_DisplayName(t *testing.T) {
+	r := NewRegistry()
+	leaders, _ := r.RegisterSub(Political, "领导人")
+	_ = r.SetDisplayName(leaders, "en", "Leaders")
+
+	tests := []struct {
+		name     string
+		category Category
+		lang     string
+		want     string
+	}{
+		{"英文", Pornography, "en", "Pornography"},
+		{"中文", Pornography, "zh", "涉黄"},
+		{"地区回退到主语言", Pornography, "en-US", "Pornography"},
+		{"未设置时使用注册名称", Pornography, "ja", "涉黄"},
+		{"子分类英文路径", leaders, "en", "Political/Leaders"},
+		{"子分类中文路径", leaders, "zh", "涉政/领导人"},
+		{"未注册分类", Category(-1 << 40), "en", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.DisplayName(tt.category, tt.lang); got != tt.want {
+				t.Errorf("DisplayName(%q) = %q, 期望 %q", tt.lang, got, tt.want)
+			}
+		})
+	}
+
+	if err := r.SetDisplayName(Category(-1<<40), "en", "X"); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("SetDisplayName(未注册) 错误 = %v, 期望 %v", err, ErrNotRegistered)
+	}
+}
+
+func TestCategory_JSON(t *testing.T) {
+	type payload struct {
+		Category Category             `json:"category"`
+		Scores   map[Category]float64 `json:"scores"`
+	}
+	in := payload{Category: Gambling, Scores: map[Category]float64{Scam: 1.5}}
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("json.Marshal() 错误 = %v", err)
+	}
+	if want := `{"category":"gambling","scores":{"scam":1.5}}`; string(data) != want {
+		t.Errorf("json.Marshal() = %s, 期望 %s", data, want)
+	}
+
+	var out payload
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json.Unmarshal() 错误 = %v", err)
+	}
+	if out.Category != Gambling || out.Scores[Scam] != 1.5 {
+		t.Errorf("json.Unmarshal() = %+v, 期望 %+v", out, in)
+	}
+
+	// 兼容数值与中文名称
+	var c Category
+	if err := json.Unmarshal([]byte(`16`), &c); err != nil || c != Gambling {
+		t.Errorf("json.Unmarshal(16) = %v, %v, 期望 %v", c, err, Gambling)
+	}
+	if err := json.Unmarshal([]byte(`"诈骗"`), &c); err != nil || c != Scam {
+		t.Errorf("json.Unmarshal(诈骗) = %v, %v, 期望 %v", c, err, Scam)
+	}
+	if err := json.Unmarshal([]byte(`"unknown"`), &c); err == nil {
+		t.Error("json.Unmarshal(unknown) 应返回错误")
+	}
+}
+
+func TestCategory_JSONAnyRegistry(t *testing.T) {
+	r := NewRegistry()
+	cat, _ := r.Register("json_registry")
+	sub, _ := r.RegisterSub(cat, "子类")
+
+	for _, c := range []Category{cat, sub} {
+		data, err := json.Marshal(c)
+		if err != nil {
+			t.Fatalf("json.Marshal() 错误 = %v", err)
+		}
+		want, _ := json.Marshal(r.Format(c))
+		if string(data) != string(want) {
+			t.Errorf("json.Marshal(%v) = %s, 期望 %s", c, data, want)
+		}
+		var out Category
+		if err := json.Unmarshal(data, &out); err != nil || out != c {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, 期望 %v", data, out, err, c)
+		}
+	}
+	if data, _ := json.Marshal(cat); string(data) != `"json_registry"` {
+		t.Errorf("json.Marshal() = %s, 期望 %q", data, "json_registry")
+	}
+
+	// 多个注册表中的同名分类无法还原
+	other := NewRegistry()
+	_, _ = other.Register("json_registry")
+	var out Category
+	if err := json.Unmarshal([]byte(`"json_registry"`), &out); err == nil {
+		t.Errorf("json.Unmarshal() = %v, 同名分类应返回错误", out)
+	}
+}
@@ -0,0 +1,78 @@
+package category
+
+import "strings"
+
+// SetDisplayName 设置分类在指定语言（如 "en"、"zh-TW"）中的显示名称，子分类只需设置自身名称
+func (r *Registry) SetDisplayName(c Category, lang, name string) error {
+	if name == "" {
+		return ErrEmptyName
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.names[c]; !ok {
+		return ErrNotRegistered
+	}
+	names := r.display[c]
+	if names == nil {
+		names = make(map[string]string)
+		r.display[c] = names
+	}
+	names[strings.ToLower(lang)] = name
+	return nil
+}
+
+// DisplayName 返回分类在指定语言中的显示名称
+//
+// 依次查找完整语言标记（如 "zh-tw"）和主语言（如 "zh"），都未设置时使用注册名称；
+// 子分类的显示名称为各级分类显示名称组成的路径。未注册的分类返回空字符串。
+func (r *Registry) DisplayName(c Category, lang string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	lang = strings.ToLower(lang)
+	var path []string
+	for cur := c; ; {
+		name, ok := r.localName(cur, lang)
+		if !ok {
+			return ""
+		}
+		path = append(path, name)
+		parent, ok := cur.Parent()
+		if !ok {
+			break
+		}
+		cur = parent
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return strings.Join(path, PathSeparator)
+}
+
+// localName 返回单级分类在指定语言中的名称，调用方需持有读锁
+func (r *Registry) localName(c Category, lang string) (string, bool) {
+	full, ok := r.names[c]
+	if !ok {
+		return "", false
+	}
+	if names := r.display[c]; names != nil {
+		if name, ok := names[lang]; ok {
+			return name, true
+		}
+		if base, _, found := strings.Cut(lang, "-"); found {
+			if name, ok := names[base]; ok {
+				return name, true
+			}
+		}
+	}
+	// 子分类的注册名称为完整路径，只取最后一级
+	if parent, ok := c.Parent(); ok {
+		if parentName, ok := r.names[parent]; ok {
+			return strings.TrimPrefix(full, parentName+PathSeparator), true
+		}
+	}
+	return full, true
+}
@@ -0,0 +1,306 @@
+package category
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrEmptyName 分类名称为空
+	ErrEmptyName = errors.New("category name cannot be empty")
+	// ErrNotRegistered 分类未注册
+	ErrNotRegistered = errors.New("category not registered")
+	// ErrStaticCategory 静态分类不能注销
+	ErrStaticCategory = errors.New("static category cannot be unregistered")
+	// ErrRegistryFull 动态分类序号已耗尽
+	ErrRegistryFull = errors.New("category registry is full")
+	// ErrInvalidParent 父分类不是已注册的单个分类
+	ErrInvalidParent = errors.New("parent category not registered")
+	// ErrHasSubCategories 分类下仍有子分类
+	ErrHasSubCategories = errors.New("category has sub-categories")
+	// ErrInvalidID 分类标识不是合法的 ASCII 标识
+	ErrInvalidID = errors.New("invalid category id")
+	// ErrDuplicateID 分类标识已被其他分类使用
+	ErrDuplicateID = errors.New("category id already in use")
+)
+
+// PathSeparator 子分类名称中分隔父分类与子分类的字符
+const PathSeparator = "/"
+
+// maxDynamicIndex 动态分类序号上限
+const maxDynamicIndex = math.MaxInt
+
+// allocated 已分配的动态分类数量，所有注册表共享，保证动态分类值全局唯一
+var allocated atomic.Int64
+
+// parents 子分类 => 父分类
+//
+// 动态分类值全局唯一，父子关系因此可以全局记录，Contains 对任一注册表中的子分类均有效。
+var parents sync.Map
+
+// dynamicNames 动态分类 => 名称
+//
+// 动态分类值全局唯一，Category.String 据此返回任一注册表中动态分类的名称。
+var dynamicNames sync.Map
+
+// owners 动态分类 => 注册它的注册表
+//
+// 分类的 JSON 与文本编码据此使用所属注册表的标识，而不只是 Default。
+var owners sync.Map
+
+// Default 默认分类注册表，包级函数均作用于该注册表
+var Default = NewRegistry()
+
+// Registry 分类注册表
+//
+// 每个注册表都包含全部静态分类，动态分类只在注册它的注册表中有效；
+// 动态分类序号在所有注册表间唯一且注销后不会被复用，避免旧词条被误归入新分类。
+// 子分类的名称为完整路径，如 "涉政/领导人"。
+// 每个分类还可以有稳定的 ASCII 标识（如 "pornography"）和各语言的显示名称。
+// Category.String 对任一注册表中的分类都返回其名称，JSON 与文本编码使用所属注册表的标识；
+// ID 与显示名称仍需通过对应注册表查询。
+type Registry struct {
+	mu      sync.RWMutex
+	byName  map[string]Category            // 名称 => 分类
+	byID    map[string]Category            // ASCII 标识 => 分类
+	names   map[Category]string            // 分类 => 名称
+	ids     map[Category]string            // 分类 => ASCII 标识
+	display map[Category]map[string]string // 分类 => 语言 => 显示名称
+}
+
+// NewRegistry 创建只包含静态分类的注册表
+func NewRegistry() *Registry {
+	r := &Registry{
+		byName:  make(map[string]Category),
+		byID:    make(map[string]Category),
+		names:   make(map[Category]string),
+		ids:     make(map[Category]string),
+		display: make(map[Category]map[string]string),
+	}
+	r.registerStatic("未分类", "none", "Uncategorized", None)
+	r.registerStatic("涉黄", "pornography", "Pornography", Pornography)
+	r.registerStatic("涉政", "political", "Political", Political)
+	r.registerStatic("暴力", "violence", "Violence", Violence)
+	r.registerStatic("赌博", "gambling", "Gambling", Gambling)
+	r.registerStatic("毒品", "drugs", "Drugs", Drugs)
+	r.registerStatic("脏话", "profanity", "Profanity", Profanity)
+	r.registerStatic("歧视", "discrimination", "Discrimination", Discrimination)
+	r.registerStatic("诈骗", "scam", "Scam", Scam)
+	r.registerStatic("自定义", "custom", "Custom", Custom)
+	return r
+}
+
+// registerStatic 注册静态分类及其中英文显示名称
+func (r *Registry) registerStatic(name, id, english string, val Category) {
+	r.byName[name] = val
+	r.byID[id] = val
+	r.names[val] = name
+	r.ids[val] = id
+	r.display[val] = map[string]string{"zh": name, "en": english}
+}
+
+// Register 注册一个动态分类，名称已存在时返回已有分类
+func (r *Registry) Register(name string) (Category, error) {
+	if name == "" {
+		return None, ErrEmptyName
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.register(name)
+}
+
+// RegisterSub 在父分类下注册一个子分类，完整名称已存在时返回已有分类
+func (r *Registry) RegisterSub(parent Category, name string) (Category, error) {
+	if name == "" {
+		return None, ErrEmptyName
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	parentName, ok := r.names[parent]
+	if !ok || parent == None {
+		return None, ErrInvalidParent
+	}
+	full := parentName + PathSeparator + name
+	if val, ok := r.byName[full]; ok {
+		return val, nil
+	}
+	val, err := r.register(full)
+	if err != nil {
+		return None, err
+	}
+	parents.Store(val, parent)
+
+	// 子分类的标识为父分类标识加子分类名称，二者均为 ASCII 时自动生成
+	if parentID, ok := r.ids[parent]; ok {
+		r.assignID(val, parentID+PathSeparator+name)
+	}
+	return val, nil
+}
+
+// register 分配动态分类，调用方需持有写锁
+func (r *Registry) register(name string) (Category, error) {
+	if val, ok := r.byName[name]; ok {
+		return val, nil
+	}
+	n := allocated.Add(1)
+	if n > int64(maxDynamicIndex-firstDynamicIndex) {
+		return None, ErrRegistryFull
+	}
+
+	val := fromIndex(firstDynamicIndex + int(n) - 1)
+	r.byName[name] = val
+	r.names[val] = name
+	dynamicNames.Store(val, name)
+	owners.Store(val, r)
+	if !strings.Contains(name, PathSeparator) {
+		r.assignID(val, name)
+	}
+	return val, nil
+}
+
+// assignID 在标识合法且未被占用时为分类设置标识，调用方需持有写锁
+func (r *Registry) assignID(c Category, id string) {
+	id = strings.ToLower(id)
+	if !validID(id) {
+		return
+	}
+	if _, taken := r.byID[id]; taken {
+		return
+	}
+	r.byID[id] = c
+	r.ids[c] = id
+}
+
+// validID 判断是否为合法的 ASCII 标识：小写字母、数字及 _ - . /，不以 / 开头或结尾
+func validID(id string) bool {
+	if id == "" || strings.HasPrefix(id, PathSeparator) || strings.HasSuffix(id, PathSeparator) {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		switch ch := id[i]; {
+		case ch >= 'a' && ch <= 'z', ch >= '0' && ch <= '9', ch == '_', ch == '-', ch == '.', ch == '/':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// SetID 为动态分类设置 ASCII 标识（不区分大小写），替换原有标识
+func (r *Registry) SetID(c Category, id string) error {
+	id = strings.ToLower(id)
+	if !validID(id) {
+		return ErrInvalidID
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.names[c]; !ok {
+		return ErrNotRegistered
+	}
+	if !c.IsDynamic() {
+		return ErrStaticCategory
+	}
+	if owner, taken := r.byID[id]; taken {
+		if owner == c {
+			return nil
+		}
+		return ErrDuplicateID
+	}
+	if old, ok := r.ids[c]; ok {
+		delete(r.byID, old)
+	}
+	r.byID[id] = c
+	r.ids[c] = id
+	return nil
+}
+
+// ID 返回分类的 ASCII 标识
+func (r *Registry) ID(c Category) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id, ok := r.ids[c]
+	return id, ok
+}
+
+// Unregister 注销动态分类
+func (r *Registry) Unregister(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	val, ok := r.byName[name]
+	if !ok {
+		return ErrNotRegistered
+	}
+	if !val.IsDynamic() {
+		return ErrStaticCategory
+	}
+	for c := range r.names {
+		if parent, ok := c.Parent(); ok && parent == val {
+			return ErrHasSubCategories
+		}
+	}
+	delete(r.byName, name)
+	delete(r.names, val)
+	if id, ok := r.ids[val]; ok {
+		delete(r.byID, id)
+		delete(r.ids, val)
+	}
+	delete(r.display, val)
+	parents.Delete(val)
+	dynamicNames.Delete(val)
+	owners.Delete(val)
+	return nil
+}
+
+// Parse 获取分类值（从名称或 ASCII 标识，标识不区分大小写）
+func (r *Registry) Parse(name string) (Category, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if val, ok := r.byName[name]; ok {
+		return val, true
+	}
+	val, ok := r.byID[strings.ToLower(name)]
+	return val, ok
+}
+
+// Name 返回分类名称
+func (r *Registry) Name(c Category) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.names[c]
+	return name, ok
+}
+
+// IsValid 判断是否为已注册的单个分类
+func (r *Registry) IsValid(c Category) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.names[c]
+	return ok
+}
+
+// All 返回所有已注册分类（不含 None）的集合
+func (r *Registry) All() *Set {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	set := NewSet()
+	for c := range r.names {
+		set.Add(c)
+	}
+	return set
+}
+
+// Len 返回已注册分类（不含 None）的数量
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.names) - 1
+}
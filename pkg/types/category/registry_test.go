@@ -0,0 +1,232 @@
+package category
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRegistry_Register(t *testing.T) {
+	r := NewRegistry()
+
+	ai, err := r.Register("AI相关")
+	if err != nil {
+		t.Fatalf("Register() 错误 = %v", err)
+	}
+	if !ai.IsDynamic() || !r.IsValid(ai) {
+		t.Errorf("Register() = %v, 期望有效的动态分类", ai)
+	}
+	if again, _ := r.Register("AI相关"); again != ai {
+		t.Errorf("重复注册返回 %v, 期望 %v", again, ai)
+	}
+	if got, ok := r.Parse("AI相关"); !ok || got != ai {
+		t.Errorf("Parse() = %v, %v, 期望 %v, true", got, ok, ai)
+	}
+	if got, ok := r.Parse("GAMBLING"); !ok || got != Gambling {
+		t.Errorf("Parse(GAMBLING) = %v, %v, 期望 %v, true", got, ok, Gambling)
+	}
+	if _, err := r.Register(""); !errors.Is(err, ErrEmptyName) {
+		t.Errorf("Register(\"\") 错误 = %v, 期望 %v", err, ErrEmptyName)
+	}
+}
+
+func TestRegistry_BeyondBitmask(t *testing.T) {
+	r := NewRegistry()
+	seen := make(map[Category]bool)
+	for i := 0; i < 200; i++ {
+		cat, err := r.Register(fmt.Sprintf("动态分类%d", i))
+		if err != nil {
+			t.Fatalf("第 %d 次注册错误 = %v", i, err)
+		}
+		if seen[cat] || cat == None {
+			t.Fatalf("第 %d 次注册返回重复的分类 %v", i, cat)
+		}
+		seen[cat] = true
+	}
+	if got, want := r.Len(), 209; got != want {
+		t.Errorf("Len() = %d, 期望 %d", got, want)
+	}
+	if got := r.All().Len(); got != 209 {
+		t.Errorf("All().Len() = %d, 期望 209", got)
+	}
+}
+
+func TestRegistry_Unregister(t *testing.T) {
+	r := NewRegistry()
+	old, _ := r.Register("临时")
+
+	if err := r.Unregister("临时"); err != nil {
+		t.Fatalf("Unregister() 错误 = %v", err)
+	}
+	if r.IsValid(old) {
+		t.Error("注销后分类仍然有效")
+	}
+	if _, ok := r.Parse("临时"); ok {
+		t.Error("注销后仍能解析分类名称")
+	}
+	// 序号不复用
+	if again, _ := r.Register("临时"); again == old {
+		t.Errorf("重新注册复用了已注销的分类 %v", old)
+	}
+
+	if err := r.Unregister("涉黄"); !errors.Is(err, ErrStaticCategory) {
+		t.Errorf("Unregister(涉黄) 错误 = %v, 期望 %v", err, ErrStaticCategory)
+	}
+	if err := r.Unregister("不存在"); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("Unregister(不存在) 错误 = %v, 期望 %v", err, ErrNotRegistered)
+	}
+}
+
+func TestRegistry_Isolation(t *testing.T) {
+	a, b := NewRegistry(), NewRegistry()
+	catA, _ := a.Register("仅A")
+
+	if _, ok := b.Parse("仅A"); ok {
+		t.Error("注册表 B 不应包含注册表 A 的分类")
+	}
+	if b.IsValid(catA) {
+		t.Error("注册表 A 的分类在注册表 B 中不应有效")
+	}
+	if _, ok := Default.Parse("仅A"); ok {
+		t.Error("默认注册表不应包含其他注册表的分类")
+	}
+}
+
+func TestCategory_StringAnyRegistry(t *testing.T) {
+	r := NewRegistry()
+	cat, _ := r.Register("其他注册表")
+	sub, _ := r.RegisterSub(cat, "子类")
+
+	if got := cat.String(); got != "其他注册表" {
+		t.Errorf("String() = %q, 期望 %q", got, "其他注册表")
+	}
+	if got := sub.String(); got != "其他注册表/子类" {
+		t.Errorf("String() = %q, 期望 %q", got, "其他注册表/子类")
+	}
+	if got := Gambling.String(); got != "赌博" {
+		t.Errorf("String() = %q, 期望 %q", got, "赌博")
+	}
+
+	_ = r.Unregister("其他注册表/子类")
+	if got, want := sub.String(), fmt.Sprintf("未知分类(%d)", sub); got != want {
+		t.Errorf("注销后 String() = %q, 期望 %q", got, want)
+	}
+}
+
+func TestList(t *testing.T) {
+	dynamic := RegisterCategory("列表测试")
+	list := List()
+
+	seen := make(map[Category]bool, len(list))
+	for _, c := range list {
+		seen[c] = true
+	}
+	for _, c := range []Category{Pornography, Custom, dynamic} {
+		if !seen[c] {
+			t.Errorf("List() 缺少分类 %v", c)
+		}
+	}
+	if seen[None] {
+		t.Error("List() 不应包含 None")
+	}
+}
+
+func TestCategory_ContainsDynamic(t *testing.T) {
+	r := NewRegistry()
+	x, _ := r.Register("X")
+	y, _ := r.Register("Y")
+
+	tests := []struct {
+		name     string
+		category Category
+		other    Category
+		want     bool
+	}{
+		{"相同动态分类", x, x, true},
+		{"不同动态分类", x, y, false},
+		{"静态包含动态", All(), x, false},
+		{"动态包含静态", x, Pornography, false},
+		{"动态包含None", x, None, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.category.Contains(tt.other); got != tt.want {
+				t.Errorf("Contains() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_RegisterSub(t *testing.T) {
+	r := NewRegistry()
+	leaders, err := r.RegisterSub(Political, "领导人")
+	if err != nil {
+		t.Fatalf("RegisterSub() 错误 = %v", err)
+	}
+	if name, _ := r.Name(leaders); name != "涉政/领导人" {
+		t.Errorf("Name() = %q, 期望 %q", name, "涉政/领导人")
+	}
+	if got, ok := r.Parse("涉政/领导人"); !ok || got != leaders {
+		t.Errorf("Parse(涉政/领导人) = %v, %v, 期望 %v, true", got, ok, leaders)
+	}
+	if again, _ := r.RegisterSub(Political, "领导人"); again != leaders {
+		t.Errorf("重复注册返回 %v, 期望 %v", again, leaders)
+	}
+	if parent, ok := leaders.Parent(); !ok || parent != Political {
+		t.Errorf("Parent() = %v, %v, 期望 %v, true", parent, ok, Political)
+	}
+
+	// 多级子分类
+	speech, _ := r.RegisterSub(leaders, "讲话")
+	if name, _ := r.Name(speech); name != "涉政/领导人/讲话" {
+		t.Errorf("Name() = %q, 期望 %q", name, "涉政/领导人/讲话")
+	}
+	if got := speech.Ancestors(); len(got) != 3 || got[1] != leaders || got[2] != Political {
+		t.Errorf("Ancestors() = %v, 期望 [%v %v %v]", got, speech, leaders, Political)
+	}
+
+	if _, err := r.RegisterSub(Political|Violence, "组合"); !errors.Is(err, ErrInvalidParent) {
+		t.Errorf("RegisterSub(组合父分类) 错误 = %v, 期望 %v", err, ErrInvalidParent)
+	}
+	if _, err := r.RegisterSub(None, "无"); !errors.Is(err, ErrInvalidParent) {
+		t.Errorf("RegisterSub(None) 错误 = %v, 期望 %v", err, ErrInvalidParent)
+	}
+	if err := r.Unregister("涉政/领导人"); !errors.Is(err, ErrHasSubCategories) {
+		t.Errorf("Unregister(有子分类) 错误 = %v, 期望 %v", err, ErrHasSubCategories)
+	}
+	if err := r.Unregister("涉政/领导人/讲话"); err != nil {
+		t.Errorf("Unregister(叶子分类) 错误 = %v", err)
+	}
+}
+
+func TestCategory_ContainsSub(t *testing.T) {
+	r := NewRegistry()
+	leaders, _ := r.RegisterSub(Political, "领导人")
+	speech, _ := r.RegisterSub(leaders, "讲话")
+	solicit, _ := r.RegisterSub(Pornography, "招嫖")
+
+	tests := []struct {
+		name     string
+		category Category
+		other    Category
+		want     bool
+	}{
+		{"父分类包含子分类", Political, leaders, true},
+		{"祖先分类包含孙分类", Political, speech, true},
+		{"组合分类包含子分类", Political | Violence, leaders, true},
+		{"子分类不包含父分类", leaders, Political, false},
+		{"其他分类不包含子分类", Political, solicit, false},
+		{"子分类包含自身", leaders, leaders, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.category.Contains(tt.other); got != tt.want {
+				t.Errorf("Contains() = %v, 期望 %v", got, tt.want)
+			}
+		})
+	}
+
+	if !NewSet(Political).Covers(speech) || NewSet(leaders).Covers(Political) {
+		t.Error("Covers() 层级判断错误")
+	}
+}
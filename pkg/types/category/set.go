@@ -0,0 +1,131 @@
+package category
+
+import "math/bits"
+
+// Set 分类集合，容量不受 64 位限制
+//
+// 零值为空集合；Set 不是并发安全的。
+type Set struct {
+	words []uint64
+}
+
+// NewSet 创建包含指定分类的集合，静态组合分类会被拆分为单个分类
+func NewSet(categories ...Category) *Set {
+	s := &Set{}
+	for _, c := range categories {
+		s.Add(c)
+	}
+	return s
+}
+
+// Add 添加分类
+func (s *Set) Add(c Category) {
+	for _, single := range c.split() {
+		i := single.index()
+		for len(s.words) <= i/64 {
+			s.words = append(s.words, 0)
+		}
+		s.words[i/64] |= 1 << (i % 64)
+	}
+}
+
+// Remove 移除分类
+func (s *Set) Remove(c Category) {
+	for _, single := range c.split() {
+		if i := single.index(); i/64 < len(s.words) {
+			s.words[i/64] &^= 1 << (i % 64)
+		}
+	}
+}
+
+// Has 判断集合是否包含分类，组合分类需全部包含
+func (s *Set) Has(c Category) bool {
+	if s == nil {
+		return false
+	}
+	singles := c.split()
+	if len(singles) == 0 {
+		return false
+	}
+	for _, single := range singles {
+		i := single.index()
+		if i/64 >= len(s.words) || s.words[i/64]&(1<<(i%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny 判断集合是否包含分类中的任意一个
+func (s *Set) HasAny(c Category) bool {
+	for _, single := range c.split() {
+		if s.Has(single) {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers 判断集合是否包含分类或其任一上级分类（组合分类包含其中任意一个即可）
+func (s *Set) Covers(c Category) bool {
+	for _, single := range c.split() {
+		for _, ancestor := range single.Ancestors() {
+			if s.Has(ancestor) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Len 返回集合中的分类数量
+func (s *Set) Len() int {
+	if s == nil {
+		return 0
+	}
+	n := 0
+	for _, w := range s.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// IsEmpty 判断集合是否为空
+func (s *Set) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Categories 按序号返回集合中的所有分类
+func (s *Set) Categories() []Category {
+	if s == nil {
+		return nil
+	}
+	var cats []Category
+	for wi, w := range s.words {
+		for ; w != 0; w &= w - 1 {
+			cats = append(cats, fromIndex(wi*64+bits.TrailingZeros64(w)))
+		}
+	}
+	return cats
+}
+
+// Union 将另一集合并入当前集合
+func (s *Set) Union(other *Set) {
+	if other == nil {
+		return
+	}
+	for len(s.words) < len(other.words) {
+		s.words = append(s.words, 0)
+	}
+	for i, w := range other.words {
+		s.words[i] |= w
+	}
+}
+
+// Clone 复制集合
+func (s *Set) Clone() *Set {
+	if s == nil {
+		return NewSet()
+	}
+	return &Set{words: append([]uint64(nil), s.words...)}
+}
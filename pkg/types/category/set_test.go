@@ -0,0 +1,71 @@
+package category
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	r := NewRegistry()
+	var dynamic []Category
+	for _, name := range []string{"一", "二", "三"} {
+		cat, _ := r.Register(name)
+		dynamic = append(dynamic, cat)
+	}
+	// 足够大的序号以跨越多个 uint64
+	for i := 0; i < 120; i++ {
+		_, _ = r.Register(string(rune('a' + i)))
+	}
+	last, _ := r.Register("最后")
+
+	s := NewSet(Pornography|Political, dynamic[1], last)
+	if got := s.Len(); got != 4 {
+		t.Errorf("Len() = %d, 期望 4", got)
+	}
+
+	tests := []struct {
+		name string
+		cat  Category
+		want bool
+	}{
+		{"静态分类", Political, true},
+		{"静态组合全部包含", Pornography | Political, true},
+		{"静态组合部分包含", Pornography | Gambling, false},
+		{"动态分类", dynamic[1], true},
+		{"未包含的动态分类", dynamic[0], false},
+		{"大序号动态分类", last, true},
+		{"None", None, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Has(tt.cat); got != tt.want {
+				t.Errorf("Has(%d) = %v, 期望 %v", tt.cat, got, tt.want)
+			}
+		})
+	}
+
+	if !s.HasAny(Pornography | Gambling) {
+		t.Error("HasAny() 应包含涉黄")
+	}
+
+	want := []Category{Pornography, Political, dynamic[1], last}
+	if got := s.Categories(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Categories() = %v, 期望 %v", got, want)
+	}
+
+	clone := s.Clone()
+	s.Remove(Political)
+	s.Remove(last)
+	if s.Has(Political) || s.Has(last) || !clone.Has(last) {
+		t.Error("Remove() 或 Clone() 行为错误")
+	}
+
+	var zero Set
+	zero.Union(clone)
+	if zero.Len() != clone.Len() {
+		t.Errorf("Union() 后 Len() = %d, 期望 %d", zero.Len(), clone.Len())
+	}
+	if (*Set)(nil).Has(Pornography) || !(*Set)(nil).IsEmpty() {
+		t.Error("nil 集合应为空")
+	}
+}
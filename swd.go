@@ -2,7 +2,10 @@
 package swd
 
 import (
+	"log/slog"
+
 	"github.com/ttofTnT/go-swd/pkg/core"
+	"github.com/ttofTnT/go-swd/pkg/detector/preprocessor"
 	"github.com/ttofTnT/go-swd/pkg/swd"
 	"github.com/ttofTnT/go-swd/pkg/types/category"
 )
@@ -11,10 +14,130 @@ import (
 type (
 	// SensitiveWord 表示一个敏感词及其相关信息
 	SensitiveWord = core.SensitiveWord
+	// WordMeta 表示敏感词的元数据（严重程度、处置动作等）
+	WordMeta = core.WordMeta
+	// RiskScore 表示文本的风险评分
+	RiskScore = core.RiskScore
+	// DetectionReport 表示结构化的检测报告
+	DetectionReport = core.DetectionReport
+	// ReportMatch 表示检测报告中的单个匹配结果
+	ReportMatch = core.ReportMatch
+	// Instrumentation 表示检测、替换和词库事件的指标采集
+	Instrumentation = core.Instrumentation
+	// WordSource 表示远程词库来源
+	WordSource = core.WordSource
+	// DictFormat 表示词库文件格式
+	DictFormat = core.DictFormat
 	// Category 表示敏感词的分类
 	Category = category.Category
+	// CategorySet 表示不受 64 位限制的分类集合
+	CategorySet = category.Set
+	// CategoryRegistry 表示分类注册表
+	CategoryRegistry = category.Registry
 	// SWD 是敏感词检测引擎的主要实现
 	SWD = swd.SWD
+	// BatchResult 表示批量处理中单条文本的结果
+	BatchResult = swd.BatchResult
+	// BatchProcessor 表示按输入顺序返回结果的并发批量处理器
+	BatchProcessor = swd.BatchProcessor
+	// Option 表示 New 的配置项
+	Option = swd.Option
+	// Profile 表示预置的检测配置
+	Profile = swd.Profile
+	// AlgorithmType 表示匹配算法类型
+	AlgorithmType = core.AlgorithmType
+	// Normalizer 表示自定义的文本标准化阶段
+	Normalizer = core.Normalizer
+	// NormalizerFunc 以函数实现 Normalizer
+	NormalizerFunc = core.NormalizerFunc
+	// Stage 表示预处理流水线中命名的标准化阶段
+	Stage = core.Stage
+	// Pipeline 表示可编辑的预处理流水线
+	Pipeline = preprocessor.Pipeline
+	// Substitutions 表示替换字符表，每个字符对应若干候选字符
+	Substitutions = core.Substitutions
+)
+
+// 导出预置检测配置常量
+const (
+	ProfileExact   = swd.ProfileExact   // 精确匹配
+	ProfileDefault = swd.ProfileDefault // 忽略大小写和全半角差异，删除不可见字符
+	ProfileStrict  = swd.ProfileStrict  // 另外按 NFKC 折叠字符，忽略数字样式差异、空白字符、emoji 和 leetspeak 替换
+)
+
+// 导出匹配算法常量
+const (
+	AlgorithmAhoCorasick = core.AlgorithmAhoCorasick // Aho-Corasick 自动机
+	AlgorithmTrie        = core.AlgorithmTrie        // 字典树
+)
+
+// WithProfile 设置预置的检测配置，默认为 ProfileExact
+func WithProfile(profile Profile) Option {
+	return swd.WithProfile(profile)
+}
+
+// WithAlgorithm 设置匹配算法，默认为 AlgorithmAhoCorasick
+func WithAlgorithm(algo AlgorithmType) Option {
+	return swd.WithAlgorithm(algo)
+}
+
+// WithWords 在默认词库之外添加词条
+func WithWords(words map[string]Category) Option {
+	return swd.WithWords(words)
+}
+
+// WithWordMeta 在默认词库之外添加带元数据的词条
+func WithWordMeta(word string, cat Category, meta WordMeta) Option {
+	return swd.WithWordMeta(word, cat, meta)
+}
+
+// WithAllowlist 添加白名单词条，落在其出现范围内的匹配会被忽略
+func WithAllowlist(words ...string) Option {
+	return swd.WithAllowlist(words...)
+}
+
+// WithPreprocessors 添加在内置预处理之后依次执行的自定义标准化阶段
+func WithPreprocessors(normalizers ...Normalizer) Option {
+	return swd.WithPreprocessors(normalizers...)
+}
+
+// WithPipeline 编辑预处理流水线，可插入、移除内置阶段或调整顺序
+func WithPipeline(edit func(p *Pipeline) error) Option {
+	return swd.WithPipeline(edit)
+}
+
+// WithSubstitutions 添加替换字符表，匹配任一候选字符组合构成的词条
+func WithSubstitutions(subs Substitutions) Option {
+	return swd.WithSubstitutions(subs)
+}
+
+// WithLogger 设置结构化日志记录器
+func WithLogger(logger *slog.Logger) Option {
+	return swd.WithLogger(logger)
+}
+
+// WithMetrics 设置指标采集
+func WithMetrics(inst Instrumentation) Option {
+	return swd.WithMetrics(inst)
+}
+
+// NewBatchProcessor 创建批量处理器，workers 不大于 0 时使用 GOMAXPROCS
+func NewBatchProcessor(workers int) *BatchProcessor {
+	return swd.NewBatchProcessor(workers)
+}
+
+// 导出词库格式常量
+const (
+	DictFormatText = core.DictFormatText // 文本格式
+	DictFormatJSON = core.DictFormatJSON // JSON 格式
+	DictFormatCSV  = core.DictFormatCSV  // CSV 格式
+)
+
+// 导出评分聚合方式常量
+const (
+	ScoreMax        = core.ScoreMax        // 取最高权重
+	ScoreSum        = core.ScoreSum        // 权重求和
+	ScoreDecayedSum = core.ScoreDecayedSum // 衰减求和
 )
 
 // 导出静态分类常量
@@ -31,30 +154,70 @@ const (
 	Custom         = category.Custom         // 自定义
 )
 
+// AllCategories 返回所有可用 | 组合的预定义分类，不包含动态分类
 func AllCategories() category.Category {
 	return category.All()
 }
 
-// RegisterCategory 用于注册动态分类
+// ListCategories 返回默认注册表中的所有分类（含动态分类），可用于 DetectIn(text, ListCategories()...)
+func ListCategories() []category.Category {
+	return category.List()
+}
+
+// RegisterCategory 用于在默认注册表中注册动态分类
 func RegisterCategory(name string) category.Category {
 	return category.RegisterCategory(name)
 }
 
+// RegisterSubCategory 用于在默认注册表中注册父分类下的子分类
+func RegisterSubCategory(parent category.Category, name string) category.Category {
+	return category.RegisterSubCategory(parent, name)
+}
+
+// UnregisterCategory 用于从默认注册表中注销动态分类
+func UnregisterCategory(name string) error {
+	return category.UnregisterCategory(name)
+}
+
+// NewCategorySet 创建分类集合
+func NewCategorySet(categories ...category.Category) *category.Set {
+	return category.NewSet(categories...)
+}
+
+// NewCategoryRegistry 创建只包含预定义分类的注册表，可通过 SWD.SetCategories 供单个实例使用
+func NewCategoryRegistry() *category.Registry {
+	return category.NewRegistry()
+}
+
+// MaskStrategy 返回按敏感词在原文中所占字符数替换的策略，replacement 不是单个字符时整词替换
+func MaskStrategy(replacement string) func(word SensitiveWord) string {
+	return swd.MaskStrategy(replacement)
+}
+
 // ParseCategory 用于解析分类名称
 func ParseCategory(name string) (category.Category, bool) {
 	return category.ParseCategory(name)
 }
 
-// New 创建一个新的敏感词检测引擎
-func New() (*SWD, error) {
-	factory := swd.NewDefaultFactory()
-	return swd.New(factory)
+// New 按配置项创建一个新的敏感词检测引擎，配置项在构建匹配算法之前统一校验
+//
+// 等同于 pkg/swd 的 NewWithOptions；pkg/swd 的 New 接受 ComponentFactory，在根包中为 NewWithFactory。
+func New(opts ...Option) (*SWD, error) {
+	return swd.NewWithOptions(opts...)
 }
 
-// NewWithFactory 使用自定义工厂创建敏感词检测引擎
+// NewWithFactory 使用自定义工厂创建敏感词检测引擎，工厂 panic 时返回错误
 func NewWithFactory(factory swd.ComponentFactory) (*SWD, error) {
 	return swd.New(factory)
 }
 
+// NewFromFactory 使用可返回错误的工厂创建敏感词检测引擎
+func NewFromFactory(factory swd.Factory) (*SWD, error) {
+	return swd.NewFromFactory(factory)
+}
+
 // ComponentFactory 定义了创建各种组件的工厂接口
 type ComponentFactory = swd.ComponentFactory
+
+// Factory 创建组件时可返回错误的工厂接口
+type Factory = swd.Factory